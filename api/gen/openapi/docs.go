@@ -15,8 +15,86 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/my-model": {
+        "/my-model/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "my-model"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by name, case-insensitive substring",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by minimum age",
+                        "name": "min_age",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by maximum age",
+                        "name": "max_age",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "-id",
+                            "name",
+                            "-name",
+                            "age",
+                            "-age"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of models to skip, can not be used with cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.listMyModelsResp"
+                        }
+                    }
+                }
+            },
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "tags": [
                     "my-model"
                 ],
@@ -33,6 +111,131 @@ const docTemplate = `{
                 ],
                 "responses": {}
             }
+        },
+        "/my-model/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "my-model"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "MyModel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.myModelResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "my-model"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "MyModel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "_",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.replaceMyModelReqBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.myModelResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "my-model"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "MyModel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "my-model"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "MyModel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update, omitted fields are left unchanged",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.updateMyModelReqBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.myModelResp"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
@@ -44,12 +247,81 @@ const docTemplate = `{
             ],
             "properties": {
                 "age": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.listMyModelsResp": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.myModelResp"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "http.myModelResp": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "id": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 }
             }
+        },
+        "http.replaceMyModelReqBody": {
+            "type": "object",
+            "required": [
+                "age",
+                "name"
+            ],
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.updateMyModelReqBody": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 1
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Bearer JWT issued by the auth service, e.g. \"Bearer \u003ctoken\u003e\". Required if authentication is enabled.",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -61,7 +333,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/api/v1/",
 	Schemes:          []string{},
 	Title:            "go-start-template API",
-	Description:      "This document contains the source for the go-start-template API",
+	Description:      "This document contains the source for the go-start-template API\n\n## Errors\n\n| Code | Type | HTTP status | gRPC code | Retryable | Message |\n|------|------|-------------|-----------|-----------|---------|\n| `ALREADY_EXISTS` | conflict | 409 Conflict | AlreadyExists | false | Resource already exists |\n| `AUTHENTICATION` | authentication | 401 Unauthorized | Unauthenticated | false | Unauthenticated |\n| `CANCELED` | canceled | 499 Client Closed Request | Canceled | false | Operation canceled |\n| `FORBIDDEN` | forbidden | 403 Forbidden | PermissionDenied | false | Forbidden |\n| `INTERNAL` | internal | 500 Internal Server Error | Internal | false | Internal server error |\n| `KAFKA_REQUEST_TIMEOUT` | timeout | 504 Gateway Timeout | DeadlineExceeded | true | Kafka request timed out |\n| `MULTIPLE_ERRORS` | validation | 400 Bad Request | InvalidArgument | false | Multiple errors occurred |\n| `NOT_FOUND` | not_found | 404 Not Found | NotFound | false | Resource not found |\n| `PAYLOAD_TOO_LARGE` | payload_too_large | 413 Request Entity Too Large | InvalidArgument | false | Payload too large |\n| `PRECONDITION_FAILED` | precondition_failed | 412 Precondition Failed | FailedPrecondition | false | Precondition failed |\n| `RATE_LIMITED` | rate_limited | 429 Too Many Requests | ResourceExhausted | false | Too many requests |\n| `TIMEOUT` | timeout | 504 Gateway Timeout | DeadlineExceeded | true | Operation timed out |\n| `UNAVAILABLE` | unavailable | 503 Service Unavailable | Unavailable | true | Service temporarily unavailable, try again later |\n| `UNSUPPORTED_MEDIA_TYPE` | unsupported_media_type | 415 Unsupported Media Type | InvalidArgument | false | Unsupported media type |\n| `VALIDATION` | validation | 400 Bad Request | InvalidArgument | false | Validation error |\n",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
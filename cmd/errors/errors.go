@@ -0,0 +1,80 @@
+package errors
+
+import (
+	"fmt"
+	"go-start-template/pkg/errx/errdoc"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ErrorsCmd returns the command that exports the catalog of error codes of the application.
+// The catalog contains the errors declared with errx.NewSentinel in all packages linked into the binary.
+func ErrorsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "errors",
+		Short: "Export the catalog of error codes",
+		Long: "Export the catalog of error codes with their type, HTTP status, gRPC code and message. " +
+			"The catalog is written to stdout if the output file is not given. " +
+			"With --header the Markdown catalog follows the content of the header file under the \"Errors\" heading, " +
+			"which is used to build the description of the swagger docs (see \"make swag\").",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			output, _ := cmd.Flags().GetString("output")
+			header, _ := cmd.Flags().GetString("header")
+
+			if format != "json" && format != "markdown" {
+				return fmt.Errorf("unknown format %q, available: json | markdown", format)
+			}
+			if header != "" && format != "markdown" {
+				return fmt.Errorf("header can only be used with markdown format")
+			}
+
+			var w io.Writer = os.Stdout
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("failed to create output file: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			var err error
+			switch {
+			case format == "json":
+				err = errdoc.WriteJSON(w, errdoc.Catalog())
+			case header != "":
+				err = writeWithHeader(w, header)
+			default:
+				err = errdoc.WriteMarkdown(w, errdoc.Catalog())
+			}
+			if err != nil {
+				return fmt.Errorf("failed to export errors: %w", err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String("format", "json", "A format of the catalog, available: json | markdown")
+	cmd.Flags().String("output", "", "A file to write the catalog to, stdout is used if empty")
+	cmd.Flags().String("header", "", "A Markdown file written before the catalog, e.g. the description of the API")
+
+	return cmd
+}
+
+// writeWithHeader writes the content of the header file followed by the Markdown catalog.
+func writeWithHeader(w io.Writer, header string) error {
+	content, err := os.ReadFile(header)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n\n## Errors\n\n", strings.TrimSpace(string(content)))
+	if err != nil {
+		return err
+	}
+	return errdoc.WriteMarkdown(w, errdoc.Catalog())
+}
@@ -0,0 +1,242 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-start-template/internal/config"
+	"go-start-template/pkg/pskafka"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/spf13/cobra"
+)
+
+// KafkaCmd returns the command with kafka tools for local development.
+// The commands use kafka configuration loaded by config.Load.
+func KafkaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kafka",
+		Short: "Produce and inspect kafka messages",
+	}
+
+	cmd.AddCommand(produceCmd(), tailCmd())
+
+	return cmd
+}
+
+func produceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "produce",
+		Short: "Produce a message to a topic",
+		Long: "Produce a message to a topic. The message value is read from the file, " +
+			"or from stdin if the file is not given. With --lines every line is produced as a separate message.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			topic, _ := cmd.Flags().GetString("topic")
+			key, _ := cmd.Flags().GetString("key")
+			file, _ := cmd.Flags().GetString("file")
+			headers, _ := cmd.Flags().GetStringArray("header")
+			lines, _ := cmd.Flags().GetBool("lines")
+
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			msgs, err := readMessages(key, file, headers, lines)
+			if err != nil {
+				return fmt.Errorf("failed to read messages: %w", err)
+			}
+
+			publisher, err := pskafka.NewPublisher(cfg.Kafka.PublisherConfig())
+			if err != nil {
+				return fmt.Errorf("failed to initialize publisher: %w", err)
+			}
+			defer publisher.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			err = publisher.Publish(ctx, topic, msgs...)
+			if err != nil {
+				return fmt.Errorf("failed to produce messages: %w", err)
+			}
+
+			log.Printf("Produced %d message(s) to %s\n", len(msgs), topic)
+			return nil
+		},
+	}
+	cmd.Flags().String("topic", "", "A topic to produce the message to")
+	cmd.Flags().String("key", "", "A key of the message")
+	cmd.Flags().String("file", "", "A file with the message value, stdin is used if empty")
+	cmd.Flags().StringArray("header", nil, "A header of the message in key=value format, can be repeated")
+	cmd.Flags().Bool("lines", false, "Produce every line of the input as a separate message")
+	_ = cmd.MarkFlagRequired("topic")
+
+	return cmd
+}
+
+func tailCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Print messages of a topic as they arrive",
+		Long: "Print messages of a topic as they arrive. The command reads every partition of the topic " +
+			"without a consumer group, so it does not affect offsets of the application and leaves no groups on the brokers.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			topic, _ := cmd.Flags().GetString("topic")
+			fromBeginning, _ := cmd.Flags().GetBool("from-beginning")
+
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			subscriberCfg := cfg.Kafka.SubscriberConfig()
+			if len(subscriberCfg.Brokers) == 0 {
+				return errors.New("no kafka brokers configured")
+			}
+
+			dialer, err := pskafka.NewDialer(subscriberCfg)
+			if err != nil {
+				return fmt.Errorf("failed to initialize dialer: %w", err)
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+			defer stop()
+
+			partitions, err := dialer.LookupPartitions(ctx, "tcp", subscriberCfg.Brokers[0], topic)
+			if err != nil {
+				return fmt.Errorf("failed to look up partitions of %s: %w", topic, err)
+			}
+			if len(partitions) == 0 {
+				return fmt.Errorf("topic %s has no partitions", topic)
+			}
+
+			offset := kafka.LastOffset
+			if fromBeginning {
+				offset = kafka.FirstOffset
+			}
+
+			var (
+				mu   sync.Mutex
+				wg   sync.WaitGroup
+				errs = make(chan error, len(partitions))
+			)
+			for _, partition := range partitions {
+				r := kafka.NewReader(kafka.ReaderConfig{
+					Brokers:   subscriberCfg.Brokers,
+					Dialer:    dialer,
+					Topic:     topic,
+					Partition: partition.ID,
+				})
+				defer r.Close()
+
+				err = r.SetOffset(offset)
+				if err != nil {
+					return fmt.Errorf("failed to set offset of partition %d: %w", partition.ID, err)
+				}
+
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for {
+						msg, err := r.ReadMessage(ctx)
+						if err != nil {
+							if ctx.Err() == nil {
+								errs <- err
+							}
+							stop()
+							return
+						}
+
+						mu.Lock()
+						printMessage(os.Stdout, msg)
+						mu.Unlock()
+					}
+				}()
+			}
+
+			wg.Wait()
+			close(errs)
+
+			if err, ok := <-errs; ok {
+				return fmt.Errorf("failed to read messages: %w", err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String("topic", "", "A topic to read messages from")
+	cmd.Flags().Bool("from-beginning", false, "Read messages from the beginning of the topic")
+	_ = cmd.MarkFlagRequired("topic")
+
+	return cmd
+}
+
+// readMessages builds messages from the file or stdin with the given key and headers.
+func readMessages(key, file string, headers []string, lines bool) ([]kafka.Message, error) {
+	var input io.Reader = os.Stdin
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		input = f
+	}
+
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+
+	msgHeaders := make([]kafka.Header, 0, len(headers))
+	for _, h := range headers {
+		k, v, ok := strings.Cut(h, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid header %q, expected key=value format", h)
+		}
+		msgHeaders = append(msgHeaders, kafka.Header{Key: k, Value: []byte(v)})
+	}
+
+	values := [][]byte{data}
+	if lines {
+		values = nil
+		for _, line := range strings.Split(string(data), "\n") {
+			if strings.TrimSpace(line) != "" {
+				values = append(values, []byte(line))
+			}
+		}
+	}
+
+	msgs := make([]kafka.Message, 0, len(values))
+	for _, value := range values {
+		msg := kafka.Message{Value: value, Headers: msgHeaders}
+		if key != "" {
+			msg.Key = []byte(key)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
+
+// printMessage writes the message metadata followed by its value.
+func printMessage(w io.Writer, msg kafka.Message) {
+	headers := make([]string, 0, len(msg.Headers))
+	for _, h := range msg.Headers {
+		headers = append(headers, fmt.Sprintf("%s=%s", h.Key, h.Value))
+	}
+
+	fmt.Fprintf(w, "%s [partition=%d offset=%d time=%s] key=%q headers=[%s]\n%s\n\n",
+		msg.Topic, msg.Partition, msg.Offset, msg.Time.Format(time.RFC3339),
+		msg.Key, strings.Join(headers, " "), msg.Value)
+}
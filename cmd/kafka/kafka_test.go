@@ -0,0 +1,76 @@
+package kafka
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+func writeInput(t *testing.T, data string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "input")
+	require.NoError(t, os.WriteFile(file, []byte(data), 0o600))
+	return file
+}
+
+func TestReadMessages(t *testing.T) {
+	file := writeInput(t, "first\n\nsecond\n")
+
+	msgs, err := readMessages("user-1", file, []string{"source=cli", "query=a=b"}, false)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	require.Equal(t, "first\n\nsecond\n", string(msgs[0].Value))
+	require.Equal(t, "user-1", string(msgs[0].Key))
+	require.Equal(t, []kafka.Header{
+		{Key: "source", Value: []byte("cli")},
+		{Key: "query", Value: []byte("a=b")},
+	}, msgs[0].Headers)
+}
+
+func TestReadMessagesLines(t *testing.T) {
+	file := writeInput(t, "first\n\n  \nsecond\n")
+
+	msgs, err := readMessages("", file, nil, true)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	require.Equal(t, "first", string(msgs[0].Value))
+	require.Equal(t, "second", string(msgs[1].Value))
+	require.Nil(t, msgs[0].Key)
+	require.Empty(t, msgs[0].Headers)
+}
+
+func TestReadMessagesErrors(t *testing.T) {
+	_, err := readMessages("", writeInput(t, "value"), []string{"no-separator"}, false)
+	require.ErrorContains(t, err, `invalid header "no-separator"`)
+
+	_, err = readMessages("", filepath.Join(t.TempDir(), "missing"), nil, false)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestPrintMessage(t *testing.T) {
+	var buf bytes.Buffer
+
+	printMessage(&buf, kafka.Message{
+		Topic:     "notifications",
+		Partition: 2,
+		Offset:    42,
+		Time:      time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Key:       []byte("user-1"),
+		Value:     []byte(`{"text":"hello"}`),
+		Headers: []kafka.Header{
+			{Key: "request-id", Value: []byte("req-1")},
+			{Key: "source", Value: []byte("cli")},
+		},
+	})
+
+	require.Equal(t,
+		"notifications [partition=2 offset=42 time=2024-05-01T12:00:00Z] key=\"user-1\" headers=[request-id=req-1 source=cli]\n"+
+			"{\"text\":\"hello\"}\n\n",
+		buf.String())
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"go-start-template/cmd/app"
+	"go-start-template/cmd/errors"
+	"go-start-template/cmd/kafka"
 	"log"
 
 	// Importing the "automaxprocs" package from Uber's Gojuno enables automatic
@@ -19,6 +21,8 @@ func main() {
 	}
 
 	rootCmd.AddCommand(app.AppCmd())
+	rootCmd.AddCommand(kafka.KafkaCmd())
+	rootCmd.AddCommand(errors.ErrorsCmd())
 
 	err := rootCmd.Execute()
 	if err != nil {
@@ -7,7 +7,10 @@ import (
 	httpServer "go-start-template/internal/handler/http"
 	"go-start-template/internal/repository/postgres"
 	"go-start-template/internal/service"
+	"go-start-template/pkg/auth"
+	"go-start-template/pkg/errx"
 	"go-start-template/pkg/logger"
+	"go-start-template/pkg/pskafka"
 	"log"
 	"net/http"
 	"os"
@@ -34,6 +37,16 @@ func Run(http_addr string) {
 	}
 	logger.Info("Initialized logger", "elapsed_time", time.Since(start).String())
 
+	// Print stacks of errors formatted with %+v while developing locally
+	errx.SetFormatStack(cfg.AppMode == config.LocalMode)
+
+	// Check that error codes clients rely on are unique
+	err = errx.CheckCodes()
+	if err != nil {
+		logger.Error("Failed to check error codes", "error", err.Error())
+		os.Exit(1)
+	}
+
 	// Initialize pgxpool.Pool
 	start = time.Now()
 	pool, err := postgres.NewConnPool(&cfg.Postgres)
@@ -49,21 +62,58 @@ func Run(http_addr string) {
 	// More repositories...
 	logger.Info("Initialized repositories", "elapsed_time", time.Since(start).String())
 
+	// Initialize authorizer of permissions, everything is allowed if authentication is disabled
+	authorizer := cfg.Auth.Authorizer()
+
 	// Initialize services
 	start = time.Now()
-	myModelSrv := service.NewMyModelSrv(logger, myModelStore)
+	myModelSrv := service.NewMyModelSrv(logger, myModelStore, authorizer)
 	// More services...
 	logger.Info("Initialized services", "elapsed_time", time.Since(start).String())
 
+	// Initialize verifier of bearer tokens
+	var verifier *auth.Verifier
+	if cfg.Auth.Enabled {
+		start = time.Now()
+		verifier, err = auth.NewVerifier(cfg.Auth.VerifierConfig())
+		if err != nil {
+			logger.Error("Failed to initialize token verifier", "error", err.Error())
+			os.Exit(1)
+		}
+		logger.Info("Initialized token verifier", "elapsed_time", time.Since(start).String())
+	}
+
 	// Initialize http Server
 	start = time.Now()
-	httpSrv, err := httpServer.New(&cfg.HttpServer, logger, cfg.AppMode, http_addr, myModelSrv)
+	httpSrv, err := httpServer.New(&cfg.HttpServer, logger, cfg.AppMode, http_addr, verifier, authorizer, myModelSrv)
 	if err != nil {
 		logger.Error("Failed to initialize httpServer", "error", err.Error())
 		os.Exit(1)
 	}
 	logger.Info("Initialized httpServer", "elapsed_time", time.Since(start).String())
 
+	// Initialize kafka subscriber
+	var subscriber *pskafka.Subscriber
+	if cfg.Kafka.Enabled {
+		start = time.Now()
+		subscriber, err = pskafka.NewSubscriber(cfg.Kafka.SubscriberConfig())
+		if err != nil {
+			logger.Error("Failed to initialize kafka subscriber", "error", err.Error())
+			os.Exit(1)
+		}
+
+		// Handle messages with the request ID of the message that caused them
+		subscriber.Use(pskafka.RequestID())
+
+		// Register your consumers here
+
+		httpSrv.AddHealthCheck("kafka", func() (bool, any) {
+			health := subscriber.Health()
+			return health.Healthy, health
+		})
+		logger.Info("Initialized kafka subscriber", "elapsed_time", time.Since(start).String())
+	}
+
 	go func() {
 		err := httpSrv.ListenAndServe()
 		if err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -73,7 +123,10 @@ func Run(http_addr string) {
 	}()
 	logger.Info("Started http server", "addr", http_addr)
 
-	// TODO: Init kafka consumer
+	if subscriber != nil {
+		go subscriber.Consume()
+		logger.Info("Started kafka subscriber")
+	}
 
 	// Graceful Shutdown
 	quit := make(chan os.Signal, 1)
@@ -101,10 +154,28 @@ func Run(http_addr string) {
 		}
 	}()
 
+	// Stop consuming kafka messages and give time to process current messages
+	if subscriber != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := subscriber.Shutdown(ctx)
+			if err != nil {
+				logger.Error("Failed to gracefully shutdown kafka subscriber", "error", err.Error())
+			} else {
+				logger.Info("Gracefully shutdown kafka subscriber", "elapsed_time", time.Since(start).String())
+			}
+		}()
+	}
+
 	// Wait for shutdown of all upstream services
 	// Then close all downstream services
 	wg.Wait()
 	pool.Close()
+	if verifier != nil {
+		verifier.Close()
+	}
 
 	logger.Info("Application shut down...")
 }
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"go-start-template/pkg/auth"
+)
+
+// VerifierConfig builds the configuration of the token verifier.
+// The internal user authenticates the requests of the JWKS to the auth service.
+func (a *Auth) VerifierConfig() auth.Config {
+	return auth.Config{
+		JWKSURL:          a.jwksURL(),
+		RefreshInterval:  a.JWKSRefreshInterval,
+		RefreshRateLimit: a.JWKSRefreshRateLimit,
+		Username:         a.InternalUser,
+		Password:         a.InternalPass,
+		PublicKeyFile:    a.PublicKeyFile,
+		Issuer:           a.Issuer,
+		Audience:         a.Audience,
+	}
+}
+
+func (a *Auth) jwksURL() string {
+	scheme := "http"
+	if a.UseTLS {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s:%d%s", scheme, a.Host, a.Port, a.JWKSPath)
+}
+
+// Authorizer builds the authorizer granting permissions to roles by the policies.
+// Everything is allowed if authentication is disabled.
+func (a *Auth) Authorizer() *auth.Authorizer {
+	if !a.Enabled {
+		return auth.AllowAll()
+	}
+	return auth.NewAuthorizer(a.Policies)
+}
@@ -7,9 +7,10 @@ type Config struct {
 	Project    Project    `yaml:"project"`
 	Logger     Logger     `yaml:"logger"`
 	HttpServer HttpServer `yaml:"http_server"`
-	// Auth       Auth
-	Postgres Postgres
+	Auth       Auth       `yaml:"auth"`
+	Postgres   Postgres
 	// Mongo      Mongo
+	Kafka Kafka `yaml:"kafka"`
 }
 
 type Project struct {
@@ -27,14 +28,29 @@ type HttpServer struct {
 	TimeOut         time.Duration `yaml:"timeout"           validate:"required"`
 	IdleTimeout     time.Duration `yaml:"idle_timeout"      validate:"required"`
 	MaxShutdownTime time.Duration `yaml:"max_shutdown_time" validate:"required"`
+	ErrorFormat     string        `yaml:"error_format"      validate:"omitempty,oneof=json problem"`
+	ProblemTypeURI  string        `yaml:"problem_type_uri"`
+	I18nDir         string        `yaml:"i18n_dir"`
+	MetricsEnabled  bool          `yaml:"metrics_enabled"`
 }
 
+// Auth is the configuration of the authentication of API requests with bearer JWTs.
+// Tokens are verified with the JWKS of the auth service, or with the public key if PublicKeyFile is set.
+// Policies map the names of roles to the permissions granted to them.
 type Auth struct {
-	Host         string `env:"AUTH_HOST"          validate:"required"`
-	Port         int32  `env:"AUTH_PORT"          validate:"required"`
-	InternalUser string `env:"AUTH_INTERNAL_USER" validate:"required"`
-	InternalPass string `env:"AUTH_INTERNAL_PASS" validate:"required"`
-	UseTLS       bool   `env:"AUTH_USE_TLS"`
+	Enabled              bool                `env:"AUTH_ENABLED"`
+	Host                 string              `env:"AUTH_HOST"            validate:"required_if=Enabled true PublicKeyFile ''"`
+	Port                 int32               `env:"AUTH_PORT"            validate:"required_if=Enabled true PublicKeyFile ''"`
+	InternalUser         string              `env:"AUTH_INTERNAL_USER"`
+	InternalPass         string              `env:"AUTH_INTERNAL_PASS"`
+	UseTLS               bool                `env:"AUTH_USE_TLS"`
+	PublicKeyFile        string              `env:"AUTH_PUBLIC_KEY_FILE"`
+	Issuer               string              `env:"AUTH_ISSUER"`
+	Audience             string              `env:"AUTH_AUDIENCE"`
+	JWKSPath             string              `yaml:"jwks_path"`
+	JWKSRefreshInterval  time.Duration       `yaml:"jwks_refresh_interval"`
+	JWKSRefreshRateLimit time.Duration       `yaml:"jwks_refresh_rate_limit"`
+	Policies             map[string][]string `yaml:"policies"`
 }
 
 type Postgres struct {
@@ -52,3 +68,14 @@ type Mongo struct {
 	User     string `env:"MONGO_USER"     validate:"required"`
 	Password string `env:"MONGO_PASSWORD" validate:"required"`
 }
+
+type Kafka struct {
+	Enabled          bool          `env:"KAFKA_ENABLED"`
+	Brokers          []string      `env:"KAFKA_BROKERS"           validate:"required_if=Enabled true"`
+	GroupID          string        `env:"KAFKA_GROUP_ID"          validate:"required_if=Enabled true"`
+	SecurityProtocol string        `env:"KAFKA_SECURITY_PROTOCOL" env-default:"PLAINTEXT"`
+	SaslAlgorithm    string        `env:"KAFKA_SASL_ALGORITHM"`
+	SaslUsername     string        `env:"KAFKA_SASL_USERNAME"`
+	SaslPassword     string        `env:"KAFKA_SASL_PASSWORD"`
+	MaxFetchInterval time.Duration `yaml:"max_fetch_interval"`
+}
@@ -0,0 +1,46 @@
+package config
+
+import "go-start-template/pkg/pskafka"
+
+// SubscriberConfig builds the pskafka subscriber configuration.
+func (k *Kafka) SubscriberConfig() *pskafka.SubscriberConfig {
+	return &pskafka.SubscriberConfig{
+		Brokers:             k.Brokers,
+		SecurityProtocol:    k.SecurityProtocol,
+		GroupID:             k.GroupID,
+		SaslPlaintextConfig: k.saslPlaintextConfig(),
+		SaslScrumConfig:     k.saslScrumConfig(),
+		MaxFetchInterval:    k.MaxFetchInterval,
+	}
+}
+
+// PublisherConfig builds the pskafka publisher configuration.
+func (k *Kafka) PublisherConfig() *pskafka.PublisherConfig {
+	return &pskafka.PublisherConfig{
+		Brokers:             k.Brokers,
+		SecurityProtocol:    k.SecurityProtocol,
+		SaslPlaintextConfig: k.saslPlaintextConfig(),
+		SaslScrumConfig:     k.saslScrumConfig(),
+	}
+}
+
+func (k *Kafka) saslPlaintextConfig() *pskafka.SaslPlaintextConfig {
+	if k.SecurityProtocol != pskafka.SaslPlaintext {
+		return nil
+	}
+	return &pskafka.SaslPlaintextConfig{
+		Username: k.SaslUsername,
+		Password: k.SaslPassword,
+	}
+}
+
+func (k *Kafka) saslScrumConfig() *pskafka.SaslScrumConfig {
+	if k.SecurityProtocol != pskafka.SaslScrum {
+		return nil
+	}
+	return &pskafka.SaslScrumConfig{
+		Algorithm: k.SaslAlgorithm,
+		Username:  k.SaslUsername,
+		Password:  k.SaslPassword,
+	}
+}
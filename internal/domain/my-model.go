@@ -10,3 +10,52 @@ type CreateMyModelParams struct {
 	Name string
 	Age  int32
 }
+
+// UpdateMyModelParams contains the fields to update, nil fields are left unchanged.
+type UpdateMyModelParams struct {
+	Name *string
+	Age  *int32
+}
+
+// Sort orders of MyModel lists. The "-" prefix means descending order.
+const (
+	SortMyModelsByID       = "id"
+	SortMyModelsByIDDesc   = "-id"
+	SortMyModelsByName     = "name"
+	SortMyModelsByNameDesc = "-name"
+	SortMyModelsByAge      = "age"
+	SortMyModelsByAgeDesc  = "-age"
+)
+
+// Limits of MyModel list pages.
+const (
+	DefaultMyModelsLimit = 20
+	MaxMyModelsLimit     = 100
+)
+
+// ListMyModelsParams contains the filters, sort order and pagination of MyModel lists.
+// The page is selected either by Offset or by Cursor returned with the previous page.
+type ListMyModelsParams struct {
+	// Name filters models whose name contains the value, case-insensitive.
+	Name string
+
+	// MinAge and MaxAge filter models by age, inclusive.
+	MinAge *int32
+	MaxAge *int32
+
+	Sort   string
+	Limit  int32
+	Offset int32
+	Cursor string
+}
+
+// MyModelList is a page of MyModel list.
+type MyModelList struct {
+	Items []MyModel
+
+	// Total is the number of models matching the filters.
+	Total int64
+
+	// NextCursor selects the next page, it is empty on the last page.
+	NextCursor string
+}
@@ -0,0 +1,8 @@
+package domain
+
+// Permissions checked by the handlers and services, they are granted to roles by the auth policies.
+const (
+	PermMyModelRead   = "my-model:read"
+	PermMyModelWrite  = "my-model:write"
+	PermMyModelDelete = "my-model:delete"
+)
@@ -1,7 +1,12 @@
 package http
 
 import (
+	_ "go-start-template/api/gen/openapi"
+	"go-start-template/internal/domain"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -10,22 +15,77 @@ func (srv *HttpServer) setupApi() {
 	r := srv.router
 
 	baseRoute := r.Group("/api/v1/")
+	if srv.verifier != nil {
+		baseRoute.Use(srv.authMiddleware())
+	}
 
 	// Register your handlers here
 	{
-		baseRoute.POST("my-model/", srv.createMyModelHandler)
+		readRoute := baseRoute.Group("my-model/", srv.RequirePermission(domain.PermMyModelRead))
+		readRoute.GET("", srv.listMyModelsHandler)
+		readRoute.GET(":id", srv.getMyModelHandler)
+
+		writeRoute := baseRoute.Group("my-model/", srv.RequirePermission(domain.PermMyModelWrite))
+		writeRoute.POST("", srv.createMyModelHandler)
+		writeRoute.PUT(":id", srv.replaceMyModelHandler)
+		writeRoute.PATCH(":id", srv.updateMyModelHandler)
+
+		deleteRoute := baseRoute.Group("my-model/", srv.RequirePermission(domain.PermMyModelDelete))
+		deleteRoute.DELETE(":id", srv.deleteMyModelHandler)
+	}
+}
+
+// HealthCheck reports whether a dependency of the application is healthy.
+// The details are included in the health endpoint response.
+type HealthCheck func() (healthy bool, details any)
+
+// AddHealthCheck registers a named health check reported by the health endpoint.
+// It must be called before the server starts serving requests.
+func (srv *HttpServer) AddHealthCheck(name string, check HealthCheck) {
+	srv.healthChecks[name] = check
+}
+
+// setupMetrics registers the endpoint of Prometheus metrics, including the counters of errors.
+// The endpoint is not authenticated, so it is registered only if enabled in the config.
+func (srv *HttpServer) setupMetrics() {
+	if !srv.serverConfig.MetricsEnabled {
+		return
 	}
+	srv.router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 }
 
+// setupHealthCheck registers the health endpoint.
+// It responds with 503 status if any of the registered health checks fails,
+// so orchestrators like Kubernetes can restart the unhealthy instance.
 func (srv *HttpServer) setupHealthCheck() {
 	srv.router.GET("/health", func(c *gin.Context) {
-		c.Status(200)
+		status := http.StatusOK
+		checks := make(map[string]any, len(srv.healthChecks))
+
+		for name, check := range srv.healthChecks {
+			healthy, details := check()
+			if !healthy {
+				status = http.StatusServiceUnavailable
+			}
+			checks[name] = details
+		}
+
+		c.JSON(status, gin.H{
+			"healthy": status == http.StatusOK,
+			"checks":  checks,
+		})
 	})
 }
 
+// The description of the API is generated from api/docs with the catalog of error codes, see "make swag".
+//
 // @title go-start-template API
-// @description This document contains the source for the go-start-template API
+// @description.markdown
 // @BasePath /api/v1/
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Bearer JWT issued by the auth service, e.g. "Bearer <token>". Required if authentication is enabled.
 func (srv *HttpServer) setupSwaggerDocs() {
 	baseRoute := srv.router.Group("/api/v1/")
 
@@ -1,123 +1,66 @@
 package http
 
 import (
+	"errors"
 	"go-start-template/pkg/errx"
+	"go-start-template/pkg/errx/errto"
+	"go-start-template/pkg/requestid"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 )
 
 func bindAndValidate(c *gin.Context, reqBody interface{}) error {
-	err := c.ShouldBindJSON(reqBody)
-	if err == nil {
-		return nil
+	if c.ContentType() != gin.MIMEJSON {
+		return errx.ErrUnsupportedMediaType.WithDetail("content_type", c.ContentType())
 	}
 
-	if errs, ok := err.(validator.ValidationErrors); ok {
-		appErr := errx.ErrValidation
-		for _, fe := range errs {
-			appErr = appErr.WithDetail(fe.Field(), fe.Tag())
-		}
-		return appErr
-	}
+	err := c.ShouldBindJSON(reqBody)
 
-	return err
+	// Validation errors are converted to ErrValidation with failed fields as field violations
+	return bindingError(err)
 }
 
-// func handleBindErr(c *gin.Context, err error) bool {
-// 	if c.ContentType() != gin.MIMEJSON {
-// 		c.JSON(http.StatusUnsupportedMediaType, gin.H{
-// 			"type":    apperr.UnsupportedPayloadType,
-// 			"message": "Invalid content type",
-// 		})
-// 		c.Error(err) //nolint: errcheck
-// 		return true
-// 	}
-
-// 	if err == nil {
-// 		return false
-// 	}
-
-// 	// Handle ValidationErrors
-// 	if errs, ok := err.(validator.ValidationErrors); ok { //nolint: errorlint
-// 		invalidArgs := parseValidationErrors(errs)
-// 		c.JSON(http.StatusBadRequest, gin.H{
-// 			"type":    apperr.Validation,
-// 			"message": "Invalid input params",
-// 			"args":    invalidArgs,
-// 		})
-// 		c.Error(err) //nolint: errcheck
-// 		return true
-// 	}
-
-// 	c.JSON(http.StatusBadRequest, gin.H{
-// 		"type":    apperr.Validation,
-// 		"message": err.Error(),
-// 	})
-// 	c.Error(err) //nolint: errcheck
-// 	return true
-// }
-
-// func handleAppErr(c *gin.Context, err error) bool {
-// 	if err == nil {
-// 		return false
-// 	}
+// bindUri binds and validates the path parameters of the request.
+func bindUri(c *gin.Context, params interface{}) error {
+	err := c.ShouldBindUri(params)
+	return bindingError(err)
+}
 
-// 	status := getStatus(err)
-// 	if status == http.StatusInternalServerError {
-// 		err = apperr.NewAppError(apperr.Internal, err.Error())
-// 		c.Error(err) //nolint: errcheck
-// 	}
+// bindQuery binds and validates the query parameters of the request.
+func bindQuery(c *gin.Context, params interface{}) error {
+	err := c.ShouldBindQuery(params)
+	return bindingError(err)
+}
 
-// 	if !c.Writer.Written() {
-// 		c.JSON(status, err)
-// 		c.Error(err) //nolint: errcheck
-// 	}
+// bindingError converts errors of gin bindings to ErrValidation.
+// Errors of values that can not be parsed (e.g. malformed JSON or a text in a numeric field)
+// are not validator errors, so they are converted here instead of errx.Wrap.
+func bindingError(err error) error {
+	if err == nil {
+		return nil
+	}
 
-// 	return true
-// }
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return errx.Wrap(err)
+	}
 
-// type invalidArgument struct {
-// 	Field string `json:"field"`
-// 	Tag   string `json:"tag"`
-// }
+	return errx.ErrValidation.WithDetail("error", err.Error())
+}
 
-// // parseValidationErrors converts BindJson errors to user friendly format
-// func parseValidationErrors(errs validator.ValidationErrors) []invalidArgument {
-// 	var invalidArgs []invalidArgument
+// writeError writes the error response in the format configured for the server.
+// The error is also attached to the gin context, so it is reported by the access logger.
+func (srv *HttpServer) writeError(c *gin.Context, err error) {
+	_ = c.Error(err)
 
-// 	for _, err := range errs {
-// 		invalidArgs = append(invalidArgs, invalidArgument{
-// 			err.Field(),
-// 			err.Tag(),
-// 		})
-// 	}
-// 	return invalidArgs
-// }
+	opts := make([]errto.Option, 0, len(srv.errOpts)+3)
+	opts = append(opts, srv.errOpts...)
+	opts = append(opts,
+		errto.WithRequest(c.Request),
+		errto.WithRoute(c.FullPath()),
+		errto.WithRequestID(requestid.FromContext(c.Request.Context())),
+	)
 
-// func getStatus(err error) int {
-// 	var e *apperr.AppError
-// 	if errors.As(err, &e) {
-// 		switch e.Type {
-// 		case apperr.Authorization:
-// 			return http.StatusUnauthorized
-// 		case apperr.Forbidden:
-// 			return http.StatusForbidden
-// 		case apperr.Validation:
-// 			return http.StatusBadRequest
-// 		case apperr.NotFound:
-// 			return http.StatusNotFound
-// 		case apperr.Conflict:
-// 			return http.StatusConflict
-// 		case apperr.PayloadTooLarge:
-// 			return http.StatusRequestEntityTooLarge
-// 		case apperr.UnsupportedPayloadType:
-// 			return http.StatusUnsupportedMediaType
-// 		case apperr.TimeOut:
-// 			return http.StatusGatewayTimeout
-// 		case apperr.Internal:
-// 			//
-// 		}
-// 	}
-// 	return http.StatusInternalServerError
-// }
+	errto.HTTP(c.Writer, err, opts...)
+}
@@ -1,8 +1,12 @@
 package http
 
 import (
+	"go-start-template/pkg/auth"
+	"go-start-template/pkg/errx"
+	"go-start-template/pkg/requestid"
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -35,20 +39,22 @@ func accessLoggerMiddleware(log *slog.Logger) gin.HandlerFunc {
 
 		log := log.
 			With("handler", "http").
+			With(requestid.LogKey, requestid.FromContext(c.Request.Context())).
 			With("method", method).
 			With("path", path).
 			With("duration", duration).
 			With("status", statusCode).
 			With("client", c.ClientIP())
 
+		// Errors attached to the context are logged at the level derived from their type
+		if ginErr := c.Errors.Last(); ginErr != nil {
+			errx.Log(c.Request.Context(), log, ginErr.Error(), ginErr.Err)
+			return
+		}
+
 		switch {
 		case statusCode >= 500:
-			errMsg := ""
-			ginErr := c.Errors.Last()
-			if ginErr != nil {
-				errMsg = ginErr.Error()
-			}
-			log.Error(errMsg)
+			log.Error("")
 		case statusCode >= 400:
 			log.Warn("")
 		default:
@@ -57,11 +63,29 @@ func accessLoggerMiddleware(log *slog.Logger) gin.HandlerFunc {
 	}
 }
 
+// requestIDMiddleware identifies the request with the ID of the X-Request-ID header, or a new ID
+// if the header is missing or invalid. The ID is put into the request context, so it is logged
+// and propagated to outgoing calls, and echoed in the X-Request-ID header of the response.
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+
+		c.Next()
+	}
+}
+
 func corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "*")
 		c.Header("Access-Control-Allow-Headers", "*")
+		c.Header("Access-Control-Expose-Headers", requestid.Header)
 
 		if c.Request.Method != "OPTIONS" {
 			c.Next()
@@ -70,3 +94,56 @@ func corsMiddleware() gin.HandlerFunc {
 		}
 	}
 }
+
+// authMiddleware authenticates requests with bearer JWTs.
+// The principal of the verified token is put into the request context, see auth.PrincipalFrom.
+// Requests without a valid token are rejected with errx.ErrAuthentication.
+func (srv *HttpServer) authMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			c.Header("WWW-Authenticate", "Bearer")
+			srv.writeError(c, errx.ErrAuthentication.WithInternalDetail("error", "missing bearer token"))
+			c.Abort()
+			return
+		}
+
+		principal, err := srv.verifier.Verify(token)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			srv.writeError(c, err)
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// bearerToken returns the token of the Authorization header with the Bearer scheme.
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// RequirePermission rejects requests of principals without the permission with errx.ErrForbidden.
+// It must be used after the authentication middleware, e.g. on route groups:
+//
+//	writeRoute := baseRoute.Group("my-model/", srv.RequirePermission(domain.PermMyModelWrite))
+func (srv *HttpServer) RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := srv.authorizer.Check(c, permission)
+		if err != nil {
+			srv.writeError(c, err)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
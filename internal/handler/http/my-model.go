@@ -2,7 +2,7 @@ package http
 
 import (
 	"go-start-template/internal/domain"
-	"go-start-template/pkg/errx/errto"
+	"go-start-template/pkg/errx"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -10,27 +10,28 @@ import (
 
 type createMyModelReqBody struct {
 	Name string `json:"name" binding:"required"`
-	Age  int32  `json:"age"  binding:"required"`
+	Age  *int32 `json:"age"  binding:"required,min=0"`
 }
 
-// @Router /my-model [post]
+// @Router /my-model/ [post]
 // @Tags my-model
+// @Security BearerAuth
 // @Param payload body createMyModelReqBody true "_"
 func (h *HttpServer) createMyModelHandler(c *gin.Context) {
 	var reqBody createMyModelReqBody
 
 	err := bindAndValidate(c, &reqBody)
 	if err != nil {
-		errto.HTTP(c.Writer, err)
+		h.writeError(c, err)
 		return
 	}
 
 	id, err := h.myModelSrv.Create(c, domain.CreateMyModelParams{
 		Name: reqBody.Name,
-		Age:  reqBody.Age,
+		Age:  *reqBody.Age,
 	})
 	if err != nil {
-		errto.HTTP(c.Writer, err)
+		h.writeError(c, err)
 		return
 	}
 
@@ -39,3 +40,212 @@ func (h *HttpServer) createMyModelHandler(c *gin.Context) {
 		"id":      id,
 	})
 }
+
+type myModelResp struct {
+	Id   int32  `json:"id"`
+	Name string `json:"name"`
+	Age  int32  `json:"age"`
+}
+
+func newMyModelResp(m domain.MyModel) myModelResp {
+	return myModelResp{
+		Id:   m.Id,
+		Name: m.Name,
+		Age:  m.Age,
+	}
+}
+
+type myModelUri struct {
+	Id int32 `uri:"id" binding:"required,min=1"`
+}
+
+// @Router /my-model/{id} [get]
+// @Tags my-model
+// @Security BearerAuth
+// @Param id path int true "MyModel ID"
+// @Success 200 {object} myModelResp
+func (h *HttpServer) getMyModelHandler(c *gin.Context) {
+	var uri myModelUri
+
+	err := bindUri(c, &uri)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	m, err := h.myModelSrv.FindOne(c, uri.Id)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newMyModelResp(m))
+}
+
+type listMyModelsQuery struct {
+	Name   string `form:"name"`
+	MinAge *int32 `form:"min_age" binding:"omitempty,min=0"`
+	MaxAge *int32 `form:"max_age" binding:"omitempty,min=0"`
+	Sort   string `form:"sort"    binding:"omitempty,oneof=id -id name -name age -age"`
+	Limit  int32  `form:"limit"   binding:"omitempty,min=1,max=100"`
+	Offset int32  `form:"offset"  binding:"omitempty,min=0"`
+	Cursor string `form:"cursor"`
+}
+
+type listMyModelsResp struct {
+	Items      []myModelResp `json:"items"`
+	Total      int64         `json:"total"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+// @Router /my-model/ [get]
+// @Tags my-model
+// @Security BearerAuth
+// @Param name query string false "Filter by name, case-insensitive substring"
+// @Param min_age query int false "Filter by minimum age"
+// @Param max_age query int false "Filter by maximum age"
+// @Param sort query string false "Sort order" Enums(id, -id, name, -name, age, -age)
+// @Param limit query int false "Page size" default(20) minimum(1) maximum(100)
+// @Param offset query int false "Number of models to skip, can not be used with cursor"
+// @Param cursor query string false "Cursor of the next page returned with the previous page"
+// @Success 200 {object} listMyModelsResp
+func (h *HttpServer) listMyModelsHandler(c *gin.Context) {
+	var query listMyModelsQuery
+
+	err := bindQuery(c, &query)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	list, err := h.myModelSrv.List(c, domain.ListMyModelsParams{
+		Name:   query.Name,
+		MinAge: query.MinAge,
+		MaxAge: query.MaxAge,
+		Sort:   query.Sort,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+		Cursor: query.Cursor,
+	})
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := listMyModelsResp{
+		Items:      make([]myModelResp, 0, len(list.Items)),
+		Total:      list.Total,
+		NextCursor: list.NextCursor,
+	}
+	for _, m := range list.Items {
+		resp.Items = append(resp.Items, newMyModelResp(m))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+type replaceMyModelReqBody struct {
+	Name string `json:"name" binding:"required"`
+	Age  *int32 `json:"age"  binding:"required,min=0"`
+}
+
+// @Router /my-model/{id} [put]
+// @Tags my-model
+// @Security BearerAuth
+// @Param id path int true "MyModel ID"
+// @Param payload body replaceMyModelReqBody true "_"
+// @Success 200 {object} myModelResp
+func (h *HttpServer) replaceMyModelHandler(c *gin.Context) {
+	var uri myModelUri
+	var reqBody replaceMyModelReqBody
+
+	err := bindUri(c, &uri)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	err = bindAndValidate(c, &reqBody)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	m, err := h.myModelSrv.Update(c, uri.Id, domain.UpdateMyModelParams{
+		Name: &reqBody.Name,
+		Age:  reqBody.Age,
+	})
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newMyModelResp(m))
+}
+
+type updateMyModelReqBody struct {
+	Name *string `json:"name" binding:"omitempty,min=1"`
+	Age  *int32  `json:"age"  binding:"omitempty,min=0"`
+}
+
+// @Router /my-model/{id} [patch]
+// @Tags my-model
+// @Security BearerAuth
+// @Param id path int true "MyModel ID"
+// @Param payload body updateMyModelReqBody true "Fields to update, omitted fields are left unchanged"
+// @Success 200 {object} myModelResp
+func (h *HttpServer) updateMyModelHandler(c *gin.Context) {
+	var uri myModelUri
+	var reqBody updateMyModelReqBody
+
+	err := bindUri(c, &uri)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	err = bindAndValidate(c, &reqBody)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	if reqBody.Name == nil && reqBody.Age == nil {
+		h.writeError(c, errx.ErrValidation.WithDetail("body", "at least one field must be set"))
+		return
+	}
+
+	m, err := h.myModelSrv.Update(c, uri.Id, domain.UpdateMyModelParams{
+		Name: reqBody.Name,
+		Age:  reqBody.Age,
+	})
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newMyModelResp(m))
+}
+
+// @Router /my-model/{id} [delete]
+// @Tags my-model
+// @Security BearerAuth
+// @Param id path int true "MyModel ID"
+// @Success 204
+func (h *HttpServer) deleteMyModelHandler(c *gin.Context) {
+	var uri myModelUri
+
+	err := bindUri(c, &uri)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	err = h.myModelSrv.Delete(c, uri.Id)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
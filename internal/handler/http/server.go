@@ -2,17 +2,26 @@ package http
 
 import (
 	"context"
+	"fmt"
 	"go-start-template/internal/config"
 	"go-start-template/internal/domain"
+	"go-start-template/pkg/auth"
+	"go-start-template/pkg/errx/errmetrics"
+	"go-start-template/pkg/errx/errto"
+	"go-start-template/pkg/errx/i18n"
 	"log/slog"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type myModelSrv interface {
 	Create(ctx context.Context, params domain.CreateMyModelParams) (int32, error)
 	FindOne(ctx context.Context, id int32) (domain.MyModel, error)
+	List(ctx context.Context, params domain.ListMyModelsParams) (domain.MyModelList, error)
+	Update(ctx context.Context, id int32, params domain.UpdateMyModelParams) (domain.MyModel, error)
+	Delete(ctx context.Context, id int32) error
 }
 
 type HttpServer struct {
@@ -23,6 +32,10 @@ type HttpServer struct {
 	router       *gin.Engine
 	myModelSrv   myModelSrv
 	addr         string
+	healthChecks map[string]HealthCheck
+	errOpts      []errto.Option
+	verifier     *auth.Verifier
+	authorizer   *auth.Authorizer
 }
 
 func New(
@@ -32,6 +45,10 @@ func New(
 	appmode string,
 	addr string,
 
+	// Verifier of bearer tokens, API requests are not authenticated if nil
+	verifier *auth.Verifier,
+	authorizer *auth.Authorizer,
+
 	// Services
 	myModelSrv myModelSrv,
 ) (
@@ -39,6 +56,11 @@ func New(
 ) {
 	setEngineMode()
 
+	errOpts, err := errorOptions(srvConfig, appmode)
+	if err != nil {
+		return nil, err
+	}
+
 	router := gin.New()
 
 	srv := &HttpServer{
@@ -47,6 +69,10 @@ func New(
 		router:       router,
 		myModelSrv:   myModelSrv,
 		addr:         addr,
+		healthChecks: make(map[string]HealthCheck),
+		errOpts:      errOpts,
+		verifier:     verifier,
+		authorizer:   authorizer,
 
 		// Ignore ReadTimeout warning since used http.TimeoutHandler instead
 		Server: &http.Server{ //nolint: gosec
@@ -61,11 +87,40 @@ func New(
 	srv.setupApi()
 	srv.setupSwaggerDocs()
 	srv.setupHealthCheck()
+	srv.setupMetrics()
 	srv.registerCustomValidators()
 
 	return srv, nil
 }
 
+// errorOptions returns the options used to write error responses of the server.
+// Internal details of errors are not exposed to clients in production mode.
+// Written errors are counted in Prometheus metrics exposed by the metrics endpoint.
+func errorOptions(srvConfig *config.HttpServer, appmode string) ([]errto.Option, error) {
+	var opts []errto.Option
+	if srvConfig.ErrorFormat == "problem" {
+		opts = append(opts, errto.WithProblemDetails(srvConfig.ProblemTypeURI))
+	}
+	if appmode == config.ProdMode {
+		opts = append(opts, errto.HideInternalDetails())
+	}
+
+	metrics, err := errmetrics.New(prometheus.DefaultRegisterer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register error metrics: %w", err)
+	}
+	opts = append(opts, errto.WithObserver(metrics))
+
+	if srvConfig.I18nDir != "" {
+		catalog, err := i18n.LoadDir(srvConfig.I18nDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load error messages: %w", err)
+		}
+		opts = append(opts, errto.WithLocalizer(catalog))
+	}
+	return opts, nil
+}
+
 func setEngineMode() {
 	// Set gin mode to release mod, so we don't need any default logs from gin
 	gin.SetMode(gin.ReleaseMode)
@@ -73,6 +128,7 @@ func setEngineMode() {
 
 func (srv *HttpServer) setupGlobalMiddlewares() {
 	srv.router.Use(
+		requestIDMiddleware(),
 		accessLoggerMiddleware(srv.log),
 		corsMiddleware(),
 		gin.Recovery(),
@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"go-start-template/internal/domain"
+	"go-start-template/pkg/errx"
+	"strings"
+)
+
+// myModelSortColumns maps sort orders of MyModel lists to the sorted columns.
+var myModelSortColumns = map[string]string{
+	domain.SortMyModelsByID:   "id",
+	domain.SortMyModelsByName: "name",
+	domain.SortMyModelsByAge:  "age",
+}
+
+// likeEscaper escapes wildcards of LIKE patterns, so they are matched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// myModelCursor is the position after the last model of a page.
+// The id makes the position unique when several models have the same sorted value.
+type myModelCursor struct {
+	Sort string `json:"s"`
+	Id   int32  `json:"i"`
+	Name string `json:"n,omitempty"`
+	Age  int32  `json:"a,omitempty"`
+}
+
+func encodeMyModelCursor(sort string, m domain.MyModel) string {
+	data, _ := json.Marshal(myModelCursor{Sort: sort, Id: m.Id, Name: m.Name, Age: m.Age})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeMyModelCursor(cursor, sort string) (myModelCursor, error) {
+	var c myModelCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || json.Unmarshal(data, &c) != nil {
+		return c, errx.ErrValidation.WithDetail("cursor", "invalid cursor")
+	}
+
+	if c.Sort != sort {
+		return c, errx.ErrValidation.WithDetail("cursor", "cursor was issued for another sort order")
+	}
+
+	return c, nil
+}
+
+// myModelListQuery is the SQL of a MyModel list page and the count of all models matching the filters.
+type myModelListQuery struct {
+	sort      string
+	countSQL  string
+	countArgs []any
+	listSQL   string
+	listArgs  []any
+}
+
+// buildMyModelListQuery builds the queries of the MyModel list page selected by the params.
+// One extra row is selected to find out whether there is a next page.
+func buildMyModelListQuery(params domain.ListMyModelsParams) (myModelListQuery, error) {
+	var q myModelListQuery
+
+	q.sort = params.Sort
+	if q.sort == "" {
+		q.sort = domain.SortMyModelsByID
+	}
+	desc := strings.HasPrefix(q.sort, "-")
+	column, ok := myModelSortColumns[strings.TrimPrefix(q.sort, "-")]
+	if !ok {
+		return q, errx.ErrValidation.WithDetail("sort", q.sort)
+	}
+
+	// Filters
+	var (
+		conditions []string
+		args       []any
+	)
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if params.Name != "" {
+		addCondition("name ILIKE '%%' || $%d || '%%'", likeEscaper.Replace(params.Name))
+	}
+	if params.MinAge != nil {
+		addCondition("age >= $%d", *params.MinAge)
+	}
+	if params.MaxAge != nil {
+		addCondition("age <= $%d", *params.MaxAge)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	q.countSQL = fmt.Sprintf(`SELECT COUNT(*) FROM "my_models"%s`, where)
+	q.countArgs = append([]any(nil), args...)
+
+	// Pagination
+	op, direction := ">", "ASC"
+	if desc {
+		op, direction = "<", "DESC"
+	}
+
+	if params.Cursor != "" {
+		cursor, err := decodeMyModelCursor(params.Cursor, q.sort)
+		if err != nil {
+			return q, err
+		}
+
+		switch column {
+		case "id":
+			addCondition("id "+op+" $%d", cursor.Id)
+		case "name":
+			args = append(args, cursor.Name, cursor.Id)
+			conditions = append(conditions, fmt.Sprintf("(name, id) %s ($%d, $%d)", op, len(args)-1, len(args)))
+		case "age":
+			args = append(args, cursor.Age, cursor.Id)
+			conditions = append(conditions, fmt.Sprintf("(age, id) %s ($%d, $%d)", op, len(args)-1, len(args)))
+		}
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	orderBy := fmt.Sprintf("%s %s", column, direction)
+	if column != "id" {
+		orderBy += fmt.Sprintf(", id %s", direction)
+	}
+
+	args = append(args, params.Limit+1, params.Offset)
+	q.listSQL = fmt.Sprintf(`SELECT id, name, age FROM "my_models"%s ORDER BY %s LIMIT $%d OFFSET $%d`,
+		where, orderBy, len(args)-1, len(args))
+	q.listArgs = args
+
+	return q, nil
+}
+
+func (store *myModelStore) List(ctx context.Context, params domain.ListMyModelsParams) (domain.MyModelList, error) {
+	var list domain.MyModelList
+
+	q, err := buildMyModelListQuery(params)
+	if err != nil {
+		return list, err
+	}
+
+	err = store.pool.QueryRow(ctx, q.countSQL, q.countArgs...).Scan(&list.Total)
+	if err != nil {
+		return list, errx.Wrap(err)
+	}
+
+	rows, err := store.pool.Query(ctx, q.listSQL, q.listArgs...)
+	if err != nil {
+		return list, errx.Wrap(err)
+	}
+	defer rows.Close()
+
+	list.Items = make([]domain.MyModel, 0, params.Limit)
+	for rows.Next() {
+		var myModel domain.MyModel
+		err = rows.Scan(&myModel.Id, &myModel.Name, &myModel.Age)
+		if err != nil {
+			return list, errx.Wrap(err)
+		}
+		list.Items = append(list.Items, myModel)
+	}
+	if err = rows.Err(); err != nil {
+		return list, errx.Wrap(err)
+	}
+
+	if int32(len(list.Items)) > params.Limit {
+		list.Items = list.Items[:params.Limit]
+		list.NextCursor = encodeMyModelCursor(q.sort, list.Items[len(list.Items)-1])
+	}
+
+	return list, nil
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"encoding/base64"
+	"go-start-template/internal/domain"
+	"go-start-template/pkg/errx"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMyModelListQuery(t *testing.T) {
+	minAge, maxAge := int32(18), int32(65)
+
+	q, err := buildMyModelListQuery(domain.ListMyModelsParams{
+		Name:   "50%_off",
+		MinAge: &minAge,
+		MaxAge: &maxAge,
+		Sort:   domain.SortMyModelsByAgeDesc,
+		Limit:  10,
+		Offset: 20,
+	})
+	require.NoError(t, err)
+
+	where := ` WHERE name ILIKE '%' || $1 || '%' AND age >= $2 AND age <= $3`
+	require.Equal(t, `SELECT COUNT(*) FROM "my_models"`+where, q.countSQL)
+	require.Equal(t, []any{`50\%\_off`, minAge, maxAge}, q.countArgs)
+	require.Equal(t, `SELECT id, name, age FROM "my_models"`+where+` ORDER BY age DESC, id DESC LIMIT $4 OFFSET $5`, q.listSQL)
+	require.Equal(t, []any{`50\%\_off`, minAge, maxAge, int32(11), int32(20)}, q.listArgs)
+}
+
+func TestBuildMyModelListQueryDefaultSort(t *testing.T) {
+	q, err := buildMyModelListQuery(domain.ListMyModelsParams{Limit: 20})
+	require.NoError(t, err)
+
+	require.Equal(t, domain.SortMyModelsByID, q.sort)
+	require.Equal(t, `SELECT COUNT(*) FROM "my_models"`, q.countSQL)
+	require.Empty(t, q.countArgs)
+	require.Equal(t, `SELECT id, name, age FROM "my_models" ORDER BY id ASC LIMIT $1 OFFSET $2`, q.listSQL)
+	require.Equal(t, []any{int32(21), int32(0)}, q.listArgs)
+
+	_, err = buildMyModelListQuery(domain.ListMyModelsParams{Sort: "email"})
+	require.ErrorIs(t, err, errx.ErrValidation)
+}
+
+func TestBuildMyModelListQueryCursor(t *testing.T) {
+	last := domain.MyModel{Id: 7, Name: "Alice", Age: 30}
+
+	tests := []struct {
+		sort     string
+		listSQL  string
+		listArgs []any
+	}{
+		{
+			sort:     domain.SortMyModelsByID,
+			listSQL:  `SELECT id, name, age FROM "my_models" WHERE age >= $1 AND id > $2 ORDER BY id ASC LIMIT $3 OFFSET $4`,
+			listArgs: []any{int32(18), int32(7), int32(11), int32(0)},
+		},
+		{
+			sort:     domain.SortMyModelsByNameDesc,
+			listSQL:  `SELECT id, name, age FROM "my_models" WHERE age >= $1 AND (name, id) < ($2, $3) ORDER BY name DESC, id DESC LIMIT $4 OFFSET $5`,
+			listArgs: []any{int32(18), "Alice", int32(7), int32(11), int32(0)},
+		},
+		{
+			sort:     domain.SortMyModelsByAge,
+			listSQL:  `SELECT id, name, age FROM "my_models" WHERE age >= $1 AND (age, id) > ($2, $3) ORDER BY age ASC, id ASC LIMIT $4 OFFSET $5`,
+			listArgs: []any{int32(18), int32(30), int32(7), int32(11), int32(0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			minAge := int32(18)
+
+			q, err := buildMyModelListQuery(domain.ListMyModelsParams{
+				MinAge: &minAge,
+				Sort:   tt.sort,
+				Limit:  10,
+				Cursor: encodeMyModelCursor(tt.sort, last),
+			})
+			require.NoError(t, err)
+
+			// The cursor only narrows the page, the total is counted with the filters
+			require.Equal(t, `SELECT COUNT(*) FROM "my_models" WHERE age >= $1`, q.countSQL)
+			require.Equal(t, []any{minAge}, q.countArgs)
+			require.Equal(t, tt.listSQL, q.listSQL)
+			require.Equal(t, tt.listArgs, q.listArgs)
+		})
+	}
+}
+
+func TestMyModelCursorRoundTrip(t *testing.T) {
+	m := domain.MyModel{Id: 42, Name: "Bob", Age: 25}
+
+	cursor, err := decodeMyModelCursor(encodeMyModelCursor(domain.SortMyModelsByNameDesc, m), domain.SortMyModelsByNameDesc)
+	require.NoError(t, err)
+	require.Equal(t, myModelCursor{Sort: domain.SortMyModelsByNameDesc, Id: 42, Name: "Bob", Age: 25}, cursor)
+}
+
+func TestDecodeMyModelCursorErrors(t *testing.T) {
+	m := domain.MyModel{Id: 42, Name: "Bob", Age: 25}
+
+	tests := []struct {
+		name   string
+		cursor string
+		detail string
+	}{
+		{"sort mismatch", encodeMyModelCursor(domain.SortMyModelsByAge, m), "cursor was issued for another sort order"},
+		{"bad base64", "not base64!", "invalid cursor"},
+		{"bad json", base64.RawURLEncoding.EncodeToString([]byte("{")), "invalid cursor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeMyModelCursor(tt.cursor, domain.SortMyModelsByName)
+
+			var e *errx.ErrorX
+			require.ErrorAs(t, err, &e)
+			require.ErrorIs(t, err, errx.ErrValidation)
+			require.Equal(t, tt.detail, e.Details["cursor"])
+		})
+	}
+
+	// Errors of the cursor are returned before the database is queried
+	_, err := buildMyModelListQuery(domain.ListMyModelsParams{Sort: domain.SortMyModelsByName, Cursor: "not base64!"})
+	require.ErrorIs(t, err, errx.ErrValidation)
+}
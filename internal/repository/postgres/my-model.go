@@ -54,7 +54,7 @@ func (store *myModelStore) FindOne(ctx context.Context, id int32) (domain.MyMode
 
 	row := store.pool.QueryRow(ctx, getUserOrganization, id)
 	var myModel domain.MyModel
-	err := row.Scan(&myModel)
+	err := row.Scan(&myModel.Id, &myModel.Name, &myModel.Age)
 
 	if err != nil {
 		return myModel, errx.Wrap(err)
@@ -63,6 +63,45 @@ func (store *myModelStore) FindOne(ctx context.Context, id int32) (domain.MyMode
 	return myModel, nil
 }
 
-func (store *myModelStore) SomeOtherMethod(ctx context.Context) error {
+func (store *myModelStore) Update(ctx context.Context, id int32, params domain.UpdateMyModelParams) (domain.MyModel, error) {
+	const updateMyModelQuery = `
+		UPDATE
+			"my_models"
+		SET
+			name = COALESCE($2, name),
+			age = COALESCE($3, age)
+		WHERE
+			id = $1
+		RETURNING id, name, age
+	`
+
+	row := store.pool.QueryRow(ctx, updateMyModelQuery, id, params.Name, params.Age)
+	var myModel domain.MyModel
+	err := row.Scan(&myModel.Id, &myModel.Name, &myModel.Age)
+
+	if err != nil {
+		return myModel, errx.Wrap(err)
+	}
+
+	return myModel, nil
+}
+
+func (store *myModelStore) Delete(ctx context.Context, id int32) error {
+	const deleteMyModelQuery = `
+		DELETE FROM
+			"my_models"
+		WHERE
+			id = $1
+	`
+
+	tag, err := store.pool.Exec(ctx, deleteMyModelQuery, id)
+	if err != nil {
+		return errx.Wrap(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errx.ErrNotFound.WithDetail("id", id)
+	}
+
 	return nil
 }
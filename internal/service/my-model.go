@@ -10,17 +10,27 @@ import (
 type myModelRepo interface {
 	Create(ctx context.Context, params domain.CreateMyModelParams) (int32, error)
 	FindOne(ctx context.Context, id int32) (domain.MyModel, error)
+	List(ctx context.Context, params domain.ListMyModelsParams) (domain.MyModelList, error)
+	Update(ctx context.Context, id int32, params domain.UpdateMyModelParams) (domain.MyModel, error)
+	Delete(ctx context.Context, id int32) error
+}
+
+// authorizer checks the permissions of the principal in the context.
+type authorizer interface {
+	Check(ctx context.Context, permission string) error
 }
 
 type myModelSrv struct {
-	log  *slog.Logger
-	repo myModelRepo
+	log   *slog.Logger
+	repo  myModelRepo
+	authz authorizer
 }
 
-func NewMyModelSrv(log *slog.Logger, repo myModelRepo) *myModelSrv {
+func NewMyModelSrv(log *slog.Logger, repo myModelRepo, authz authorizer) *myModelSrv {
 	return &myModelSrv{
-		log:  log,
-		repo: repo,
+		log:   log,
+		repo:  repo,
+		authz: authz,
 	}
 }
 
@@ -36,3 +46,37 @@ func (srv *myModelSrv) FindOne(ctx context.Context, id int32) (domain.MyModel, e
 	m, err := srv.repo.FindOne(ctx, id)
 	return m, errx.Wrap(err)
 }
+
+func (srv *myModelSrv) List(ctx context.Context, params domain.ListMyModelsParams) (domain.MyModelList, error) {
+	if params.Cursor != "" && params.Offset > 0 {
+		return domain.MyModelList{}, errx.ErrValidation.
+			WithDetail("offset", "offset can not be used with cursor")
+	}
+
+	if params.Limit <= 0 {
+		params.Limit = domain.DefaultMyModelsLimit
+	}
+	if params.Limit > domain.MaxMyModelsLimit {
+		params.Limit = domain.MaxMyModelsLimit
+	}
+
+	list, err := srv.repo.List(ctx, params)
+	return list, errx.Wrap(err)
+}
+
+func (srv *myModelSrv) Update(ctx context.Context, id int32, params domain.UpdateMyModelParams) (domain.MyModel, error) {
+	// Some other business logic
+	m, err := srv.repo.Update(ctx, id, params)
+	return m, errx.Wrap(err)
+}
+
+func (srv *myModelSrv) Delete(ctx context.Context, id int32) error {
+	// Deleting requires its own permission, it is checked here so every caller of the service is authorized
+	err := srv.authz.Check(ctx, domain.PermMyModelDelete)
+	if err != nil {
+		return err
+	}
+
+	err = srv.repo.Delete(ctx, id)
+	return errx.Wrap(err)
+}
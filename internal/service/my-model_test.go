@@ -0,0 +1,65 @@
+package service_test
+
+import (
+	"context"
+	"go-start-template/internal/domain"
+	"go-start-template/internal/service"
+	"go-start-template/pkg/errx"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMyModelRepo records the params of List calls.
+type fakeMyModelRepo struct {
+	listParams []domain.ListMyModelsParams
+}
+
+func (r *fakeMyModelRepo) Create(context.Context, domain.CreateMyModelParams) (int32, error) {
+	return 0, nil
+}
+
+func (r *fakeMyModelRepo) FindOne(context.Context, int32) (domain.MyModel, error) {
+	return domain.MyModel{}, nil
+}
+
+func (r *fakeMyModelRepo) List(_ context.Context, params domain.ListMyModelsParams) (domain.MyModelList, error) {
+	r.listParams = append(r.listParams, params)
+	return domain.MyModelList{}, nil
+}
+
+func (r *fakeMyModelRepo) Update(context.Context, int32, domain.UpdateMyModelParams) (domain.MyModel, error) {
+	return domain.MyModel{}, nil
+}
+
+func (r *fakeMyModelRepo) Delete(context.Context, int32) error {
+	return nil
+}
+
+func TestListMyModelsOffsetWithCursor(t *testing.T) {
+	repo := &fakeMyModelRepo{}
+	srv := service.NewMyModelSrv(slog.Default(), repo, nil)
+
+	_, err := srv.List(context.Background(), domain.ListMyModelsParams{Offset: 10, Cursor: "eyJzIjoiaWQiLCJpIjoxfQ"})
+
+	var e *errx.ErrorX
+	require.ErrorAs(t, err, &e)
+	require.ErrorIs(t, err, errx.ErrValidation)
+	require.Equal(t, "offset can not be used with cursor", e.Details["offset"])
+	require.Empty(t, repo.listParams)
+}
+
+func TestListMyModelsLimit(t *testing.T) {
+	repo := &fakeMyModelRepo{}
+	srv := service.NewMyModelSrv(slog.Default(), repo, nil)
+
+	for _, limit := range []int32{0, 1000} {
+		_, err := srv.List(context.Background(), domain.ListMyModelsParams{Limit: limit})
+		require.NoError(t, err)
+	}
+
+	require.Len(t, repo.listParams, 2)
+	require.Equal(t, int32(domain.DefaultMyModelsLimit), repo.listParams[0].Limit)
+	require.Equal(t, int32(domain.MaxMyModelsLimit), repo.listParams[1].Limit)
+}
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Existing rows are cleaned up, so the constraints can be added to a populated table:
+-- missing names and ages are backfilled with empty values, and rows sharing an id
+-- (inserted with explicit ids) get new ids from the sequence, keeping the first row of every id.
+UPDATE "my_models" SET "name" = '' WHERE "name" IS NULL;
+UPDATE "my_models" SET "age" = 0 WHERE "age" IS NULL;
+
+SELECT setval(pg_get_serial_sequence('my_models', 'id'), COALESCE(MAX("id"), 0) + 1, false) FROM "my_models";
+
+UPDATE "my_models"
+SET "id" = nextval(pg_get_serial_sequence('my_models', 'id'))
+WHERE ctid IN (
+    SELECT ctid FROM (
+        SELECT ctid, ROW_NUMBER() OVER (PARTITION BY "id" ORDER BY ctid) AS n FROM "my_models"
+    ) AS duplicates
+    WHERE n > 1
+);
+
+ALTER TABLE "my_models"
+    ADD PRIMARY KEY ("id"),
+    ALTER COLUMN "name" SET NOT NULL,
+    ALTER COLUMN "age" SET NOT NULL;
+
+CREATE INDEX "my_models_name_id_idx" ON "my_models" ("name", "id");
+CREATE INDEX "my_models_age_id_idx" ON "my_models" ("age", "id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS "my_models_age_id_idx";
+DROP INDEX IF EXISTS "my_models_name_id_idx";
+
+ALTER TABLE "my_models"
+    DROP CONSTRAINT IF EXISTS "my_models_pkey",
+    ALTER COLUMN "name" DROP NOT NULL,
+    ALTER COLUMN "age" DROP NOT NULL;
+-- +goose StatementEnd
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"go-start-template/pkg/ds"
+	"go-start-template/pkg/errx"
+	"strings"
+)
+
+// AllPermissions grants all permissions when it is granted to a role.
+// A permission ending with ":*" grants all permissions with the prefix, e.g. "my-model:*".
+const AllPermissions = "*"
+
+// Authorizer checks the permissions of the principal in the context.
+// Permissions are granted to principals only by their roles according to the policies,
+// the scopes of tokens do not grant permissions.
+type Authorizer struct {
+	policies map[string]ds.Set[string]
+	disabled bool
+}
+
+// NewAuthorizer returns an authorizer granting the permissions to the roles by the policies,
+// policies map the names of roles to their permissions.
+func NewAuthorizer(policies map[string][]string) *Authorizer {
+	a := &Authorizer{policies: make(map[string]ds.Set[string], len(policies))}
+	for role, permissions := range policies {
+		a.policies[role] = ds.NewSet(permissions...)
+	}
+	return a
+}
+
+// AllowAll returns an authorizer that allows everything, even without a principal.
+// It is used when authentication is disabled.
+func AllowAll() *Authorizer {
+	return &Authorizer{disabled: true}
+}
+
+// Check returns nil if the principal in the context has the permission.
+// It returns errx.ErrAuthentication if there is no principal in the context,
+// or errx.ErrForbidden with the missing permission as a detail.
+func (a *Authorizer) Check(ctx context.Context, permission string) error {
+	if a.disabled {
+		return nil
+	}
+
+	p, ok := PrincipalFrom(ctx)
+	if !ok {
+		return errx.ErrAuthentication
+	}
+
+	if !a.Allowed(p, permission) {
+		return errx.ErrForbidden.WithDetail("permission", permission)
+	}
+	return nil
+}
+
+// Allowed reports whether the principal has the permission.
+func (a *Authorizer) Allowed(p *Principal, permission string) bool {
+	if a.disabled {
+		return true
+	}
+
+	for _, role := range p.Roles {
+		for granted := range a.policies[role] {
+			if grants(granted, permission) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// grants reports whether the granted permission includes the permission.
+func grants(granted, permission string) bool {
+	if granted == AllPermissions || granted == permission {
+		return true
+	}
+
+	prefix, ok := strings.CutSuffix(granted, AllPermissions)
+	return ok && strings.HasSuffix(prefix, ":") && strings.HasPrefix(permission, prefix)
+}
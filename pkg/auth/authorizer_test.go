@@ -0,0 +1,57 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"go-start-template/pkg/auth"
+	"go-start-template/pkg/errx"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizerCheck(t *testing.T) {
+	authorizer := auth.NewAuthorizer(map[string][]string{
+		"admin":  {auth.AllPermissions},
+		"editor": {"my-model:*"},
+		"viewer": {"my-model:read"},
+	})
+
+	tests := []struct {
+		name       string
+		principal  *auth.Principal
+		permission string
+		allowed    bool
+	}{
+		{"all permissions", &auth.Principal{Roles: []string{"admin"}}, "other:write", true},
+		{"permission of role", &auth.Principal{Roles: []string{"viewer"}}, "my-model:read", true},
+		{"missing permission", &auth.Principal{Roles: []string{"viewer"}}, "my-model:write", false},
+		{"prefix wildcard", &auth.Principal{Roles: []string{"editor"}}, "my-model:delete", true},
+		{"prefix wildcard of other resource", &auth.Principal{Roles: []string{"editor"}}, "my-models:read", false},
+		{"unknown role", &auth.Principal{Roles: []string{"guest"}}, "my-model:read", false},
+		{"scope does not grant permission", &auth.Principal{Scopes: []string{"my-model:write"}}, "my-model:write", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authorizer.Check(auth.WithPrincipal(context.Background(), tt.principal), tt.permission)
+			if tt.allowed {
+				require.NoError(t, err)
+				return
+			}
+
+			var e *errx.ErrorX
+			require.True(t, errors.As(err, &e))
+			require.True(t, errors.Is(err, errx.ErrForbidden))
+			require.Equal(t, tt.permission, e.Details["permission"])
+		})
+	}
+}
+
+func TestAuthorizerCheckWithoutPrincipal(t *testing.T) {
+	err := auth.NewAuthorizer(nil).Check(context.Background(), "my-model:read")
+	require.True(t, errors.Is(err, errx.ErrAuthentication))
+
+	err = auth.AllowAll().Check(context.Background(), "my-model:read")
+	require.NoError(t, err)
+}
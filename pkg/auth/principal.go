@@ -0,0 +1,37 @@
+// Package auth authenticates requests with bearer JWTs.
+//
+// Tokens are verified with the keys of a JWKS fetched from the auth service and cached,
+// or with a static public key. The principal of a verified token is put into the context
+// of the request, so handlers and services can identify the caller with PrincipalFrom.
+package auth
+
+import "context"
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+
+	// Subject identifies the caller, it is the "sub" claim of the token.
+	Subject string
+
+	// Roles are the roles of the caller from the "roles" claim.
+	Roles []string
+
+	// Scopes are the scopes of the token from the space separated "scope" claim.
+	Scopes []string
+
+	// Claims are all claims of the token.
+	Claims map[string]any
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of the context carrying the principal.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFrom returns the principal carried by the context.
+func PrincipalFrom(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
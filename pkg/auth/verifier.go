@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-start-template/pkg/errx"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MicahParks/keyfunc"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// signingMethods are the accepted signing algorithms of tokens.
+// Only asymmetric algorithms are accepted, so a public key can not be used as an HMAC secret.
+var signingMethods = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// Config is the configuration of the Verifier.
+type Config struct {
+
+	// JWKSURL is the URL of the JWKS of the auth service.
+	JWKSURL string
+
+	// RefreshInterval is the interval of refreshing the cached JWKS in the background.
+	// The JWKS is also refreshed when a token is signed with an unknown key,
+	// at most once per RefreshRateLimit.
+	RefreshInterval  time.Duration
+	RefreshRateLimit time.Duration
+
+	// Username and Password authenticate the requests of the JWKS, if set.
+	Username string
+	Password string
+
+	// PublicKeyFile is the path to the PEM encoded public key (RSA, ECDSA or Ed25519)
+	// that verifies tokens instead of the JWKS.
+	PublicKeyFile string
+
+	// Issuer and Audience are the required "iss" and "aud" claims of tokens, if set.
+	Issuer   string
+	Audience string
+}
+
+// Verifier verifies bearer JWTs and extracts the principal.
+type Verifier struct {
+	parser   *jwt.Parser
+	keyfunc  jwt.Keyfunc
+	jwks     *keyfunc.JWKS
+	issuer   string
+	audience string
+}
+
+// NewVerifier returns a verifier of tokens signed with the static public key if configured,
+// otherwise with the keys of the JWKS. The JWKS is fetched before NewVerifier returns
+// and refreshed in the background until Close is called.
+func NewVerifier(cfg Config) (*Verifier, error) {
+	v := &Verifier{
+		parser:   jwt.NewParser(jwt.WithValidMethods(signingMethods)),
+		issuer:   cfg.Issuer,
+		audience: cfg.Audience,
+	}
+
+	if cfg.PublicKeyFile != "" {
+		key, err := loadPublicKey(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		v.keyfunc = func(*jwt.Token) (interface{}, error) { return key, nil }
+		return v, nil
+	}
+
+	jwks, err := keyfunc.Get(cfg.JWKSURL, keyfunc.Options{
+		RefreshInterval:   cfg.RefreshInterval,
+		RefreshRateLimit:  cfg.RefreshRateLimit,
+		RefreshUnknownKID: true,
+		RequestFactory: func(ctx context.Context, url string) (*http.Request, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				return nil, err
+			}
+			if cfg.Username != "" {
+				req.SetBasicAuth(cfg.Username, cfg.Password)
+			}
+			return req, nil
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get JWKS from %s: %w", cfg.JWKSURL, err)
+	}
+	v.jwks = jwks
+	v.keyfunc = jwks.Keyfunc
+	return v, nil
+}
+
+// Verify verifies the signature and claims of the token and returns its principal.
+// It returns errx.ErrAuthentication if the token is invalid, the reason is an internal detail.
+// Tokens without expiration time are rejected, so a leaked token can not be used forever.
+func (v *Verifier) Verify(tokenString string) (*Principal, error) {
+	claims := jwt.MapClaims{}
+
+	_, err := v.parser.ParseWithClaims(tokenString, claims, v.keyfunc)
+	if err != nil {
+		return nil, errx.ErrAuthentication.WithInternalDetail("error", err.Error())
+	}
+
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errx.ErrAuthentication.WithInternalDetail("error", "token has no expiration time")
+	}
+	if v.issuer != "" && !claims.VerifyIssuer(v.issuer, true) {
+		return nil, errx.ErrAuthentication.WithInternalDetail("error", "token has invalid issuer")
+	}
+	if v.audience != "" && !claims.VerifyAudience(v.audience, true) {
+		return nil, errx.ErrAuthentication.WithInternalDetail("error", "token has invalid audience")
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, errx.ErrAuthentication.WithInternalDetail("error", "token has no subject")
+	}
+
+	scope, _ := claims["scope"].(string)
+
+	return &Principal{
+		Subject: subject,
+		Roles:   stringsClaim(claims["roles"]),
+		Scopes:  strings.Fields(scope),
+		Claims:  claims,
+	}, nil
+}
+
+// Close stops refreshing the JWKS in the background.
+func (v *Verifier) Close() {
+	if v.jwks != nil {
+		v.jwks.EndBackground()
+	}
+}
+
+func loadPublicKey(path string) (interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseECPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("failed to parse public key: not a PEM encoded RSA, ECDSA or Ed25519 public key")
+}
+
+// stringsClaim returns the strings of a claim which is either an array of strings or a single string.
+func stringsClaim(claim any) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"go-start-template/pkg/auth"
+	"go-start-template/pkg/errx"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+)
+
+const testKID = "test-key"
+
+// jwksStub serves the JWKS of the key, requests must be authenticated with the internal user.
+func jwksStub(t *testing.T, key *rsa.PrivateKey) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "internal" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]any{{
+				"kty": "RSA",
+				"kid": testKID,
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testKID
+	s, err := token.SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "user-1",
+		"iss":   "auth-service",
+		"aud":   "go-start-template",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"admin"},
+		"scope": "my-model:read my-model:write",
+	}
+}
+
+func TestVerifierJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	srv := jwksStub(t, key)
+
+	verifier, err := auth.NewVerifier(auth.Config{
+		JWKSURL:  srv.URL,
+		Username: "internal",
+		Password: "secret",
+		Issuer:   "auth-service",
+		Audience: "go-start-template",
+	})
+	require.NoError(t, err)
+	defer verifier.Close()
+
+	t.Run("valid token", func(t *testing.T) {
+		principal, err := verifier.Verify(signToken(t, key, validClaims()))
+		require.NoError(t, err)
+		require.Equal(t, "user-1", principal.Subject)
+		require.Equal(t, []string{"admin"}, principal.Roles)
+		require.Equal(t, []string{"my-model:read", "my-model:write"}, principal.Scopes)
+
+		ctx := auth.WithPrincipal(context.Background(), principal)
+		p, ok := auth.PrincipalFrom(ctx)
+		require.True(t, ok)
+		require.Equal(t, principal, p)
+	})
+
+	invalid := map[string]string{
+		"malformed token": "not-a-token",
+		"unknown key":     signToken(t, otherKey, validClaims()),
+	}
+
+	expired := validClaims()
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+	invalid["expired token"] = signToken(t, key, expired)
+
+	noExpiration := validClaims()
+	delete(noExpiration, "exp")
+	invalid["no expiration time"] = signToken(t, key, noExpiration)
+
+	otherIssuer := validClaims()
+	otherIssuer["iss"] = "other"
+	invalid["invalid issuer"] = signToken(t, key, otherIssuer)
+
+	otherAudience := validClaims()
+	otherAudience["aud"] = "other"
+	invalid["invalid audience"] = signToken(t, key, otherAudience)
+
+	noSubject := validClaims()
+	delete(noSubject, "sub")
+	invalid["no subject"] = signToken(t, key, noSubject)
+
+	hmac := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims())
+	hmac.Header["kid"] = testKID
+	invalid["symmetric algorithm"], err = hmac.SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	for name, token := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := verifier.Verify(token)
+			require.True(t, errors.Is(err, errx.ErrAuthentication))
+		})
+	}
+}
+
+func TestVerifierJWKSUnauthorized(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	srv := jwksStub(t, key)
+
+	_, err = auth.NewVerifier(auth.Config{JWKSURL: srv.URL})
+	require.Error(t, err)
+}
+
+func TestVerifierPublicKeyFile(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "public.pem")
+	err = os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600)
+	require.NoError(t, err)
+
+	verifier, err := auth.NewVerifier(auth.Config{PublicKeyFile: path})
+	require.NoError(t, err)
+	defer verifier.Close()
+
+	principal, err := verifier.Verify(signToken(t, key, validClaims()))
+	require.NoError(t, err)
+	require.Equal(t, "user-1", principal.Subject)
+}
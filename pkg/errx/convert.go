@@ -0,0 +1,78 @@
+package errx
+
+import "sync"
+
+// Converter converts a foreign error (e.g. a database driver or gRPC error) to an ErrorX.
+// It returns false if the error is not recognized by the converter.
+type Converter func(err error) (*ErrorX, bool)
+
+var (
+	convertersMu     sync.RWMutex
+	customConverters []Converter
+	builtinEnabled   = true
+
+	// builtinConverters are tried in order after the registered converters.
+	// Context errors are checked before net errors, since context.DeadlineExceeded
+	// also implements the net.Error interface.
+	builtinConverters = []Converter{
+		fromPG,
+		fromGRPC,
+		fromMongo,
+		fromContext,
+		fromNet,
+		fromValidator,
+	}
+)
+
+// RegisterConverter registers a converter used by Wrap.
+// Registered converters are tried in registration order before the built-in converters,
+// so they can override the built-in conversions. The first converter that recognizes
+// the error wins.
+//
+// Converters are intended to be registered once at application startup:
+//
+//	errx.RegisterConverter(func(err error) (*errx.ErrorX, bool) {
+//		if errors.Is(err, redis.Nil) {
+//			return errx.ErrNotFound, true
+//		}
+//		return nil, false
+//	})
+func RegisterConverter(c Converter) {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+
+	customConverters = append(customConverters, c)
+}
+
+// DisableBuiltinConverters disables the built-in converters for PostgreSQL, gRPC,
+// MongoDB, context, net and validator errors, so only registered converters are used.
+func DisableBuiltinConverters() {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+
+	builtinEnabled = false
+}
+
+// convert converts the error with the first converter that recognizes it.
+func convert(err error) (*ErrorX, bool) {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+
+	for _, c := range customConverters {
+		if e, ok := c(err); ok {
+			return e, true
+		}
+	}
+
+	if !builtinEnabled {
+		return nil, false
+	}
+
+	for _, c := range builtinConverters {
+		if e, ok := c(err); ok {
+			return e, true
+		}
+	}
+
+	return nil, false
+}
@@ -1,55 +1,105 @@
 package errx
 
 import (
+	"context"
 	"errors"
 	"go-start-template/pkg/errx/internal/errpb"
+	"net"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
+	pgxv5 "github.com/jackc/pgx/v5"
+	pgconnv5 "github.com/jackc/pgx/v5/pgconn"
+	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-func fromPG(err error) error {
-	var pgErr *pgconn.PgError
-	if errors.As(err, &pgErr) {
-		switch pgErr.Code {
-		case "23505", "21000":
-			return ErrConflict.
-				WithDetail("constraint", pgErr.ConstraintName)
-		}
+// PostgreSQL error codes (SQLSTATE) converted to ErrorX.
+// See https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation      = "23505"
+	pgCardinalityViolation = "21000"
+	pgForeignKeyViolation  = "23503"
+	pgNotNullViolation     = "23502"
+	pgCheckViolation       = "23514"
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+	pgQueryCanceled        = "57014"
+)
+
+// fromPG converts errors of both pgx v4 and pgx v5 drivers.
+func fromPG(err error) (*ErrorX, bool) {
+	var (
+		pgErr   *pgconn.PgError
+		pgErrV5 *pgconnv5.PgError
+	)
+	switch {
+	case errors.As(err, &pgErr):
+		return fromPGCode(err, pgErr.Code, pgErr.ConstraintName, pgErr.ColumnName)
+	case errors.As(err, &pgErrV5):
+		return fromPGCode(err, pgErrV5.Code, pgErrV5.ConstraintName, pgErrV5.ColumnName)
+	case errors.Is(err, pgx.ErrNoRows), errors.Is(err, pgxv5.ErrNoRows):
+		return ErrNotFound, true
 	}
-	if errors.Is(err, pgx.ErrNoRows) {
-		return ErrNotFound
+
+	return nil, false
+}
+
+func fromPGCode(err error, code, constraint, column string) (*ErrorX, bool) {
+	switch code {
+	case pgUniqueViolation, pgCardinalityViolation:
+		return ErrConflict.
+			WithInternalDetail("constraint", constraint), true
+	case pgForeignKeyViolation, pgCheckViolation:
+		return ErrValidation.
+			WithInternalDetail("constraint", constraint), true
+	case pgNotNullViolation:
+		return ErrValidation.
+			WithInternalDetail("column", column), true
+	case pgSerializationFailure, pgDeadlockDetected:
+		return ErrUnavailable.
+			WithInternalDetail("error", err.Error()).
+			WithRetryable(true), true
+	case pgQueryCanceled:
+		return ErrTimeout.
+			WithInternalDetail("error", err.Error()).
+			WithRetryable(true), true
 	}
 
-	return err
+	return nil, false
 }
 
-func fromGRPC(err error) error {
+func fromGRPC(err error) (*ErrorX, bool) {
 	st, ok := status.FromError(err)
 	if !ok {
-		return err
+		return nil, false
 	}
 
 	for _, detail := range st.Details() {
 		if pb, ok := detail.(*errpb.ErrorX); ok {
-			return fromProto(pb)
+			return fromProto(pb), true
 		}
 	}
 
-	return fromGRPCCode(st)
+	return fromGRPCCode(st), true
 }
 
 func fromGRPCCode(st *status.Status) *ErrorX {
 	msg := st.Message()
 
 	grpcToAppErr := map[codes.Code]*ErrorX{
-		codes.AlreadyExists:    ErrConflict,
-		codes.NotFound:         ErrNotFound,
-		codes.PermissionDenied: ErrForbidden,
-		codes.Unauthenticated:  ErrAuthentication,
+		codes.AlreadyExists:      ErrConflict,
+		codes.NotFound:           ErrNotFound,
+		codes.PermissionDenied:   ErrForbidden,
+		codes.Unauthenticated:    ErrAuthentication,
+		codes.DeadlineExceeded:   ErrTimeout,
+		codes.Canceled:           ErrCanceled,
+		codes.Unavailable:        ErrUnavailable,
+		codes.ResourceExhausted:  ErrRateLimited,
+		codes.FailedPrecondition: ErrPreconditionFailed,
 	}
 
 	if st.Code() == codes.InvalidArgument {
@@ -57,32 +107,124 @@ func fromGRPCCode(st *status.Status) *ErrorX {
 	}
 
 	if e, found := grpcToAppErr[st.Code()]; found {
-		return e.WithDetail("error", msg)
+		return e.
+			WithInternalDetail("error", msg).
+			WithRetryable(st.Code() == codes.Unavailable || st.Code() == codes.DeadlineExceeded)
 	}
 
-	return ErrInternal.WithDetail("error", msg)
+	return ErrInternal.WithInternalDetail("error", msg)
 }
 
 func handleInvalidArgument(st *status.Status, msg string) *ErrorX {
-	err := ErrValidation.WithDetail("error", msg)
+	err := ErrValidation.WithInternalDetail("error", msg)
 	for _, detail := range st.Details() {
 		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
 			for _, violation := range badRequest.GetFieldViolations() {
-				err = err.WithDetail(violation.GetField(), violation.GetDescription())
+				err = err.WithFieldViolations(FieldViolation{
+					Field:   violation.GetField(),
+					Message: violation.GetDescription(),
+				})
 			}
 		}
 	}
 	return err
 }
 
+// fromProto creates the error received from another service.
+// The error is not recorded in the registry, since it is not declared by the application.
 func fromProto(pberr *errpb.ErrorX) *ErrorX {
 	err := New(
 		Type(pberr.GetType()),
 		pberr.Message,
 		pberr.Code,
 	)
+	// The sender resolves the retryability, so it is kept even if it differs from the type default
+	retryable := pberr.GetRetryable()
+	err.Retryable = &retryable
+	details := make(map[string]any, len(pberr.Details))
 	for k, v := range pberr.Details {
+		details[k] = v
+	}
+	// Typed details are not set by senders that are not aware of them
+	if pberr.TypedDetails != nil {
+		details = pberr.TypedDetails.AsMap()
+	}
+
+	for k, v := range details {
 		err = err.WithDetail(k, v)
 	}
+	for _, k := range pberr.InternalDetails {
+		if v, ok := details[k]; ok {
+			err = err.WithInternalDetail(k, v)
+		}
+	}
+	for _, v := range pberr.Violations {
+		err = err.WithFieldViolations(FieldViolation{
+			Field:   v.GetField(),
+			Rule:    v.GetRule(),
+			Param:   v.GetParam(),
+			Message: v.GetMessage(),
+		})
+	}
+	for _, item := range pberr.Items {
+		if item.GetError() == nil {
+			continue
+		}
+		err.Items = append(err.Items, ItemError{
+			Index: int(item.GetIndex()),
+			Error: fromProto(item.GetError()),
+		})
+	}
 	return err
 }
+
+func fromMongo(err error) (*ErrorX, bool) {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return ErrNotFound, true
+	case mongo.IsDuplicateKeyError(err):
+		return ErrConflict.WithInternalDetail("error", err.Error()), true
+	case mongo.IsTimeout(err):
+		return ErrTimeout.WithInternalDetail("error", err.Error()).WithRetryable(true), true
+	case mongo.IsNetworkError(err):
+		return ErrUnavailable.WithInternalDetail("error", err.Error()).WithRetryable(true), true
+	}
+	return nil, false
+}
+
+func fromContext(err error) (*ErrorX, bool) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrTimeout.WithRetryable(true), true
+	case errors.Is(err, context.Canceled):
+		return ErrCanceled, true
+	}
+	return nil, false
+}
+
+func fromNet(err error) (*ErrorX, bool) {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrTimeout.WithInternalDetail("error", err.Error()).WithRetryable(true), true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return ErrUnavailable.WithInternalDetail("error", err.Error()).WithRetryable(true), true
+	}
+
+	return nil, false
+}
+
+func fromValidator(err error) (*ErrorX, bool) {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return nil, false
+	}
+
+	violations := make([]FieldViolation, 0, len(errs))
+	for _, fe := range errs {
+		violations = append(violations, fieldViolation(fe))
+	}
+	return ErrValidation.WithFieldViolations(violations...), true
+}
@@ -0,0 +1,108 @@
+package errx_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-start-template/pkg/errx"
+	"net"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	pgxv5 "github.com/jackc/pgx/v5"
+	pgconnv5 "github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWrapPostgresErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want *errx.ErrorX
+	}{
+		{"v4 no rows", pgx.ErrNoRows, errx.ErrNotFound},
+		{"v5 no rows", fmt.Errorf("find: %w", pgxv5.ErrNoRows), errx.ErrNotFound},
+		{"v4 unique violation", &pgconn.PgError{Code: "23505"}, errx.ErrConflict},
+		{"v5 unique violation", &pgconnv5.PgError{Code: "23505"}, errx.ErrConflict},
+		{"foreign key violation", &pgconnv5.PgError{Code: "23503"}, errx.ErrValidation},
+		{"not null violation", &pgconnv5.PgError{Code: "23502"}, errx.ErrValidation},
+		{"check violation", &pgconnv5.PgError{Code: "23514"}, errx.ErrValidation},
+		{"serialization failure", &pgconnv5.PgError{Code: "40001"}, errx.ErrUnavailable},
+		{"deadlock", &pgconnv5.PgError{Code: "40P01"}, errx.ErrUnavailable},
+		{"query canceled", &pgconnv5.PgError{Code: "57014"}, errx.ErrTimeout},
+		{"unknown code", &pgconnv5.PgError{Code: "XX000"}, errx.ErrInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := errx.Wrap(tt.err)
+			require.True(t, errors.Is(err, tt.want), "got %v", err)
+		})
+	}
+}
+
+func TestWrapPostgresErrorDetails(t *testing.T) {
+	err := errx.Wrap(&pgconnv5.PgError{Code: "23505", ConstraintName: "users_email_key"})
+
+	var e *errx.ErrorX
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, "users_email_key", e.Details["constraint"])
+}
+
+func TestWrapBuiltinConverters(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want *errx.ErrorX
+	}{
+		{"context deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), errx.ErrTimeout},
+		{"context canceled", context.Canceled, errx.ErrCanceled},
+		{"mongo no documents", mongo.ErrNoDocuments, errx.ErrNotFound},
+		{"net timeout", &net.DNSError{IsTimeout: true}, errx.ErrTimeout},
+		{"net dial", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, errx.ErrUnavailable},
+		{"grpc status", status.Error(codes.NotFound, "not found"), errx.ErrNotFound},
+		{"unknown", errors.New("unknown"), errx.ErrInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := errx.Wrap(tt.err)
+			require.True(t, errors.Is(err, tt.want), "got %v", err)
+		})
+	}
+}
+
+func TestRegisterConverter(t *testing.T) {
+	errCustom := errors.New("custom")
+	errx.RegisterConverter(func(err error) (*errx.ErrorX, bool) {
+		if errors.Is(err, errCustom) {
+			return errx.ErrForbidden, true
+		}
+		return nil, false
+	})
+
+	require.True(t, errors.Is(errx.Wrap(errCustom), errx.ErrForbidden))
+
+	// Registered converters take precedence over the built-in ones
+	err := fmt.Errorf("%w: %w", errCustom, pgx.ErrNoRows)
+	require.True(t, errors.Is(errx.Wrap(err), errx.ErrForbidden))
+}
+
+func TestWrapKeepsCause(t *testing.T) {
+	pgErr := &pgconnv5.PgError{Code: "23505", ConstraintName: "users_email_key"}
+	err := errx.Wrap(fmt.Errorf("create user: %w", pgErr))
+
+	require.True(t, errors.Is(err, errx.ErrConflict))
+	require.False(t, errors.Is(err, errx.ErrNotFound))
+
+	var target *pgconnv5.PgError
+	require.True(t, errors.As(err, &target))
+	require.Equal(t, pgErr, target)
+
+	// The cause is kept when the error is wrapped again
+	require.True(t, errors.As(errx.Wrap(err), &target))
+}
@@ -2,30 +2,40 @@ package errx
 
 const (
 	// Default error codes
-	CodeInternal       = "INTERNAL"
-	CodeAuthentication = "AUTHENTICATION"
-	CodeForbidden      = "FORBIDDEN"
-	CodeValidation     = "VALIDATION"
-	CodeNotFound       = "NOT_FOUND"
-	CodeConflict       = "ALREADY_EXISTS"
+	CodeInternal             = "INTERNAL"
+	CodeAuthentication       = "AUTHENTICATION"
+	CodeForbidden            = "FORBIDDEN"
+	CodeValidation           = "VALIDATION"
+	CodeNotFound             = "NOT_FOUND"
+	CodeConflict             = "ALREADY_EXISTS"
+	CodeTimeout              = "TIMEOUT"
+	CodeUnavailable          = "UNAVAILABLE"
+	CodeCanceled             = "CANCELED"
+	CodeRateLimited          = "RATE_LIMITED"
+	CodePayloadTooLarge      = "PAYLOAD_TOO_LARGE"
+	CodePreconditionFailed   = "PRECONDITION_FAILED"
+	CodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+	CodeMultiple             = "MULTIPLE_ERRORS"
 )
 
 var (
 	// Default errors used for common error scenarios.
-	ErrInternal       = New(Internal, "Internal server error", CodeInternal)
-	ErrAuthentication = New(Authentication, "Unauthenticated", CodeAuthentication)
-	ErrForbidden      = New(Forbidden, "Forbidden", CodeForbidden)
-	ErrValidation     = New(Validation, "Validation error", CodeValidation)
-	ErrNotFound       = New(NotFound, "Resource not found", CodeNotFound)
-	ErrConflict       = New(Conflict, "Resource already exists", CodeConflict)
-)
-
+	ErrInternal             = NewSentinel(Internal, "Internal server error", CodeInternal)
+	ErrAuthentication       = NewSentinel(Authentication, "Unauthenticated", CodeAuthentication)
+	ErrForbidden            = NewSentinel(Forbidden, "Forbidden", CodeForbidden)
+	ErrValidation           = NewSentinel(Validation, "Validation error", CodeValidation)
+	ErrNotFound             = NewSentinel(NotFound, "Resource not found", CodeNotFound)
+	ErrConflict             = NewSentinel(Conflict, "Resource already exists", CodeConflict)
+	ErrTimeout              = NewSentinel(Timeout, "Operation timed out", CodeTimeout)
+	ErrUnavailable          = NewSentinel(Unavailable, "Service temporarily unavailable, try again later", CodeUnavailable)
+	ErrCanceled             = NewSentinel(Canceled, "Operation canceled", CodeCanceled)
+	ErrRateLimited          = NewSentinel(RateLimited, "Too many requests", CodeRateLimited)
+	ErrPayloadTooLarge      = NewSentinel(PayloadTooLarge, "Payload too large", CodePayloadTooLarge)
+	ErrPreconditionFailed   = NewSentinel(PreconditionFailed, "Precondition failed", CodePreconditionFailed)
+	ErrUnsupportedMediaType = NewSentinel(UnsupportedMediaType, "Unsupported media type", CodeUnsupportedMediaType)
 
-// app codes
-var (
-	CodeOrderStatusInvalid = "invalid_status"
+	// ErrMultiple aggregates errors of a batch operation, see Join.
+	// Joined errors take the dominant type of their items, the declared Validation type
+	// is the type of the common case of batch items rejected by validation.
+	ErrMultiple = NewSentinel(Validation, "Multiple errors occurred", CodeMultiple)
 )
-
-func do () {
-	
-}
@@ -0,0 +1,75 @@
+// Package errdoc documents the errors registered with errx.NewSentinel.
+//
+// The catalog lists the code, type, HTTP status, gRPC code, retryability and message of every error,
+// so clients have a single list of error codes they can rely on.
+// It is exported by the "errors" command and included in the swagger docs.
+package errdoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-start-template/pkg/errx"
+	"go-start-template/pkg/errx/errto"
+	"io"
+	"strings"
+)
+
+// Entry describes a registered error.
+type Entry struct {
+	Code       string `json:"code"`
+	Type       string `json:"type"`
+	HTTPStatus int    `json:"http_status"`
+	GRPCCode   string `json:"grpc_code"`
+	Message    string `json:"message"`
+	Retryable  bool   `json:"retryable"`
+}
+
+// Catalog returns the entries of the registered errors sorted by code.
+func Catalog() []Entry {
+	errs := errx.Registered()
+
+	entries := make([]Entry, 0, len(errs))
+	for _, e := range errs {
+		entries = append(entries, Entry{
+			Code:       e.Code,
+			Type:       e.Type.String(),
+			HTTPStatus: errto.HTTPStatus(e),
+			GRPCCode:   errto.GRPCCode(e).String(),
+			Message:    e.Message,
+			Retryable:  errx.IsRetryable(e),
+		})
+	}
+	return entries
+}
+
+// WriteJSON writes the catalog as an indented JSON array.
+func WriteJSON(w io.Writer, entries []Entry) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+// WriteMarkdown writes the catalog as a Markdown table.
+func WriteMarkdown(w io.Writer, entries []Entry) error {
+	var b strings.Builder
+	b.WriteString("| Code | Type | HTTP status | gRPC code | Retryable | Message |\n")
+	b.WriteString("|------|------|-------------|-----------|-----------|---------|\n")
+	for _, e := range entries {
+		fmt.Fprintf(&b, "| `%s` | %s | %d %s | %s | %t | %s |\n",
+			e.Code, e.Type, e.HTTPStatus, errto.StatusText(e.HTTPStatus), e.GRPCCode, e.Retryable, escapeMarkdown(e.Message))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Markdown returns the catalog of the registered errors as a Markdown table.
+func Markdown() string {
+	var b strings.Builder
+	_ = WriteMarkdown(&b, Catalog())
+	return b.String()
+}
+
+func escapeMarkdown(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,51 @@
+// Package errmetrics exports counters of errors written by errto.HTTP and errto.GRPC to Prometheus.
+//
+// Metrics implements errto.Observer and is intended to be passed with errto.WithObserver option:
+//
+//	metrics, err := errmetrics.New(prometheus.DefaultRegisterer)
+//	...
+//	errto.HTTP(w, err, errto.WithObserver(metrics), errto.WithRoute("/api/v1/my-model/:id"))
+package errmetrics
+
+import (
+	"errors"
+	"go-start-template/pkg/errx"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics counts errors by transport, type, code and route.
+type Metrics struct {
+	errors *prometheus.CounterVec
+}
+
+// New creates the metrics and registers them in the registerer.
+// If the metrics are already registered (e.g. by another server of the application),
+// the registered counters are reused.
+func New(reg prometheus.Registerer) (*Metrics, error) {
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "errx_errors_total",
+		Help: "The number of errors written to responses by type, code and route.",
+	}, []string{"transport", "type", "code", "route"})
+
+	err := reg.Register(counter)
+	if err != nil {
+		var alreadyRegistered prometheus.AlreadyRegisteredError
+		if !errors.As(err, &alreadyRegistered) {
+			return nil, err
+		}
+		existing, ok := alreadyRegistered.ExistingCollector.(*prometheus.CounterVec)
+		if !ok {
+			return nil, err
+		}
+		counter = existing
+	}
+
+	return &Metrics{errors: counter}, nil
+}
+
+// ObserveError increments the counter of the error.
+// Errors joined with errx.Join are counted once with their own code.
+func (m *Metrics) ObserveError(transport, route string, e *errx.ErrorX) {
+	m.errors.WithLabelValues(transport, e.Type.String(), e.Code, route).Inc()
+}
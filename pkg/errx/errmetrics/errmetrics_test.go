@@ -0,0 +1,38 @@
+package errmetrics_test
+
+import (
+	"go-start-template/pkg/errx"
+	"go-start-template/pkg/errx/errmetrics"
+	"go-start-template/pkg/errx/errto"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	metrics, err := errmetrics.New(reg)
+	require.NoError(t, err)
+
+	// Registering again reuses the counters
+	_, err = errmetrics.New(reg)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		errto.HTTP(httptest.NewRecorder(), errx.ErrConflict,
+			errto.WithObserver(metrics), errto.WithRoute("/api/v1/my-model/"))
+	}
+	_ = errto.GRPC(errx.ErrNotFound, errto.WithObserver(metrics), errto.WithRoute("/models.Models/Get"))
+
+	expected := `
+# HELP errx_errors_total The number of errors written to responses by type, code and route.
+# TYPE errx_errors_total counter
+errx_errors_total{code="ALREADY_EXISTS",route="/api/v1/my-model/",transport="http",type="conflict"} 2
+errx_errors_total{code="NOT_FOUND",route="/models.Models/Get",transport="grpc",type="not_found"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "errx_errors_total"))
+}
@@ -1,17 +1,22 @@
 package errto
 
 import (
+	"encoding/json"
 	"fmt"
 	"go-start-template/pkg/errx"
 	"go-start-template/pkg/errx/internal/errpb"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 // errto.GRPC converts an error to a gRPC status error.
 // This function is intended for use in gRPC server handlers to convert ErrorX instances to gRPC status errors.
-func GRPC(err error) error {
+// Only HideInternalDetails, WithObserver and WithRoute options affect gRPC status errors.
+func GRPC(err error, opts ...Option) error {
 	if err == nil {
 		return nil
 	}
@@ -21,19 +26,20 @@ func GRPC(err error) error {
 		err = errx.Wrap(err)
 	}
 
-	return toStatus(err).Err()
+	o := newOptions(opts)
+	if e, ok := err.(*errx.ErrorX); ok {
+		o.observe("grpc", e)
+	}
+
+	return toStatus(err, o).Err()
 }
 
-func toStatus(err error) *status.Status {
+func toStatus(err error, o *options) *status.Status {
 	if e, ok := err.(*errx.ErrorX); ok {
-		st, dtErr := status.New(gRPCStatusCode(e), e.Message).WithDetails(
-			&errpb.ErrorX{
-				Message: e.Message,
-				Code:    e.Code,
-				Type:    int32(e.Type),
-				Details: e.Details,
-			},
-		)
+		if o.hideInternal {
+			e = e.WithoutInternalDetails()
+		}
+		st, dtErr := status.New(GRPCCode(e), e.Message).WithDetails(statusDetails(e)...)
 		if dtErr == nil {
 			return st
 		}
@@ -42,7 +48,62 @@ func toStatus(err error) *status.Status {
 	return status.New(codes.Internal, err.Error())
 }
 
-func gRPCStatusCode(err error) codes.Code {
+// statusDetails returns the details of the gRPC status of the error.
+// Field violations are also added as errdetails.BadRequest, so they are understood
+// by clients that are not aware of ErrorX.
+func statusDetails(e *errx.ErrorX) []protoadapt.MessageV1 {
+	pb := toProto(e)
+	if len(e.Violations) == 0 {
+		return []protoadapt.MessageV1{pb}
+	}
+
+	badRequest := &errdetails.BadRequest{
+		FieldViolations: make([]*errdetails.BadRequest_FieldViolation, 0, len(e.Violations)),
+	}
+	for _, v := range e.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Message,
+		})
+	}
+	return []protoadapt.MessageV1{pb, badRequest}
+}
+
+func toProto(e *errx.ErrorX) *errpb.ErrorX {
+	pb := &errpb.ErrorX{
+		Message:         e.Message,
+		Code:            e.Code,
+		Type:            int32(e.Type),
+		Details:         stringDetails(e.Details),
+		TypedDetails:    typedDetails(e.Details),
+		InternalDetails: e.InternalDetailKeys(),
+		Retryable:       errx.IsRetryable(e),
+	}
+	for _, v := range e.Violations {
+		pb.Violations = append(pb.Violations, &errpb.FieldViolation{
+			Field:   v.Field,
+			Rule:    v.Rule,
+			Param:   v.Param,
+			Message: v.Message,
+		})
+	}
+	for _, item := range e.Items {
+		pb.Items = append(pb.Items, &errpb.ItemError{
+			Index: int32(item.Index),
+			Error: toProto(item.Error),
+		})
+	}
+	return pb
+}
+
+// GRPCCode returns the gRPC status code errto.GRPC uses for the error.
+// Errors that are not ErrorX result in codes.Internal.
+//
+// PayloadTooLarge and UnsupportedMediaType errors have no gRPC code of their own and are
+// sent as codes.InvalidArgument. The type of the error is carried in the status details,
+// so errx.Wrap restores it on the receiving side; only a plain status without the details
+// (e.g. from a service not using errto) is received as a Validation error.
+func GRPCCode(err error) codes.Code {
 	if e, ok := err.(*errx.ErrorX); ok {
 		switch e.Type {
 		case errx.Authentication:
@@ -55,9 +116,69 @@ func gRPCStatusCode(err error) codes.Code {
 			return codes.NotFound
 		case errx.Conflict:
 			return codes.AlreadyExists
+		case errx.Timeout:
+			return codes.DeadlineExceeded
+		case errx.Canceled:
+			return codes.Canceled
+		case errx.Unavailable:
+			return codes.Unavailable
+		case errx.RateLimited:
+			return codes.ResourceExhausted
+		case errx.PayloadTooLarge, errx.UnsupportedMediaType:
+			return codes.InvalidArgument
+		case errx.PreconditionFailed:
+			return codes.FailedPrecondition
 		case errx.Internal:
 			return codes.Internal
 		}
 	}
 	return codes.Internal
 }
+
+// stringDetails returns the details with values that are not strings encoded as JSON,
+// so the details are understood by receivers that are not aware of typed details.
+func stringDetails(details map[string]any) map[string]string {
+	if len(details) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(details))
+	for k, v := range details {
+		if str, ok := v.(string); ok {
+			result[k] = str
+			continue
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			result[k] = fmt.Sprint(v)
+			continue
+		}
+		result[k] = string(data)
+	}
+	return result
+}
+
+// typedDetails returns the details as a protobuf Struct.
+// The details are normalized through JSON, so values of any serializable type
+// are encoded the same way as in HTTP responses.
+func typedDetails(details map[string]any) *structpb.Struct {
+	if len(details) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(details)
+	if err != nil {
+		return nil
+	}
+
+	var normalized map[string]any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil
+	}
+
+	st, err := structpb.NewStruct(normalized)
+	if err != nil {
+		return nil
+	}
+	return st
+}
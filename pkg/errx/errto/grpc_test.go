@@ -0,0 +1,110 @@
+package errto_test
+
+import (
+	"errors"
+	"go-start-template/pkg/errx"
+	"go-start-template/pkg/errx/errto"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCInternalDetails(t *testing.T) {
+	err := errx.ErrConflict.
+		WithDetail("id", "42").
+		WithInternalDetail("constraint", "my_model_name_key")
+
+	var e *errx.ErrorX
+	require.True(t, errors.As(errx.Wrap(errto.GRPC(err)), &e))
+	require.Equal(t, map[string]any{"id": "42", "constraint": "my_model_name_key"}, e.Details)
+	require.True(t, e.IsInternalDetail("constraint"))
+	require.False(t, e.IsInternalDetail("id"))
+
+	require.True(t, errors.As(errx.Wrap(errto.GRPC(err, errto.HideInternalDetails())), &e))
+	require.Equal(t, map[string]any{"id": "42"}, e.Details)
+}
+
+func TestGRPCFieldViolations(t *testing.T) {
+	violation := errx.FieldViolation{Field: "items[0].name", Rule: "required", Message: "items[0].name is required"}
+	err := errto.GRPC(errx.ErrValidation.WithFieldViolations(violation))
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		if d, ok := detail.(*errdetails.BadRequest); ok {
+			badRequest = d
+		}
+	}
+	require.NotNil(t, badRequest)
+	require.Equal(t, "items[0].name", badRequest.GetFieldViolations()[0].GetField())
+	require.Equal(t, "items[0].name is required", badRequest.GetFieldViolations()[0].GetDescription())
+
+	var e *errx.ErrorX
+	require.True(t, errors.As(errx.Wrap(err), &e))
+	require.Equal(t, []errx.FieldViolation{violation}, e.Violations)
+}
+
+func TestGRPCPlainFieldViolations(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "invalid order").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "items[0].name", Description: "name is required"},
+			{Field: "items[1].name", Description: "name is too long"},
+		},
+	})
+	require.NoError(t, err)
+
+	var e *errx.ErrorX
+	require.True(t, errors.As(errx.Wrap(st.Err()), &e))
+	require.True(t, errors.Is(e, errx.ErrValidation))
+	require.Equal(t, []errx.FieldViolation{
+		{Field: "items[0].name", Message: "name is required"},
+		{Field: "items[1].name", Message: "name is too long"},
+	}, e.Violations)
+	require.Equal(t, map[string]any{"error": "invalid order"}, e.Details)
+}
+
+func TestGRPCJoin(t *testing.T) {
+	err := errto.GRPC(errx.Join(errx.ErrNotFound, nil, errx.ErrNotFound.WithDetail("id", "3")))
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.NotFound, st.Code())
+
+	var e *errx.ErrorX
+	require.True(t, errors.As(errx.Wrap(err), &e))
+	require.Equal(t, errx.CodeMultiple, e.Code)
+	require.Len(t, e.Items, 2)
+	require.Equal(t, 2, e.Items[1].Index)
+	require.Equal(t, map[string]any{"id": "3"}, e.Items[1].Error.Details)
+}
+
+func TestGRPCTypedDetails(t *testing.T) {
+	err := errto.GRPC(errx.ErrValidation.
+		WithDetail("ids", []int{1, 2}).
+		WithDetail("limit", 100).
+		WithDetail("name", "test"))
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+
+	var e *errx.ErrorX
+	require.True(t, errors.As(errx.Wrap(err), &e))
+	require.Equal(t, map[string]any{
+		"ids":   []any{float64(1), float64(2)},
+		"limit": float64(100),
+		"name":  "test",
+	}, e.Details)
+
+	// Receivers that are not aware of typed details get the details encoded as JSON
+	for _, detail := range st.Details() {
+		if pb, ok := detail.(interface{ GetDetails() map[string]string }); ok {
+			require.Equal(t, map[string]string{"ids": "[1,2]", "limit": "100", "name": "test"}, pb.GetDetails())
+		}
+	}
+}
@@ -11,7 +11,13 @@ import (
 // This function intended for use in HTTP handlers to convert ErrorX instances to HTTP responses.
 // If the error is nil, no response is written.
 // If the error is a gRPC status error, it is first converted to an ErrorX using errfrom.GRPC.
-func HTTP(w http.ResponseWriter, err error) {
+//
+// By default the body contains the message, code and details of the error.
+// Use WithProblemDetails option to write RFC 7807 problem details instead
+// and HideInternalDetails option to strip internal details from the body.
+// With WithLocalizer option the message is written in the language of the Accept-Language header.
+// With WithRequestID option the body contains the ID of the request.
+func HTTP(w http.ResponseWriter, err error, opts ...Option) {
 	if err == nil {
 		return
 	}
@@ -20,26 +26,124 @@ func HTTP(w http.ResponseWriter, err error) {
 		err = errx.Wrap(err)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(httpStatusCode(err))
-	writeBody(w, err)
+	o := newOptions(opts)
+	status := HTTPStatus(err)
+
+	if e, ok := err.(*errx.ErrorX); ok {
+		o.observe("http", e)
+	}
+
+	contentType := "application/json"
+	if o.problemDetails {
+		contentType = ContentTypeProblem
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	writeBody(w, err, status, o)
+}
+
+// defaultBody is the body of errors written in the default format.
+type defaultBody struct {
+	*errx.ErrorX
+	RequestID string `json:"request_id,omitempty"`
 }
 
-func writeBody(w http.ResponseWriter, err error) {
+// fallbackBody is the body written when the error can not be marshaled.
+type fallbackBody struct {
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+func writeBody(w http.ResponseWriter, err error, status int, o *options) {
 	if e, ok := err.(*errx.ErrorX); ok {
-		errJson, marshalErr := json.Marshal(e)
+		if o.hideInternal {
+			e = e.WithoutInternalDetails()
+		}
+		if o.localizer != nil && o.request != nil {
+			if msg, ok := o.localizer.Localize(e, o.request.Header.Get("Accept-Language")); ok {
+				e = e.WithMessage(msg)
+			}
+		}
+		e = marshalableDetails(e)
+
+		var body any = defaultBody{ErrorX: e, RequestID: o.requestID}
+		if o.problemDetails {
+			body = newProblemDetails(e, status, o)
+		}
+
+		errJson, marshalErr := json.Marshal(body)
 		if marshalErr == nil {
 			_, _ = w.Write(errJson)
 			return
 		}
 		err = fmt.Errorf("marshal error: %w. original error: %w", marshalErr, err)
 	}
-	_, _ = w.Write(
-		[]byte(fmt.Sprintf(`{"message": "%s", "code": "%s"}`, err.Error(), errx.CodeInternal)),
-	)
+
+	errJson, _ := json.Marshal(fallbackBody{Message: err.Error(), Code: errx.CodeInternal})
+	_, _ = w.Write(errJson)
+}
+
+// marshalableDetails returns the error with detail values that can not be encoded as JSON
+// (channels, functions, cyclic values, ...) stringified, including details of the item errors,
+// so a single detail does not break the whole response.
+func marshalableDetails(e *errx.ErrorX) *errx.ErrorX {
+	var details map[string]any
+	for k, v := range e.Details {
+		if _, err := json.Marshal(v); err == nil {
+			continue
+		}
+		if details == nil {
+			details = make(map[string]any, len(e.Details))
+			for k, v := range e.Details {
+				details[k] = v
+			}
+		}
+		details[k] = fmt.Sprint(v)
+	}
+
+	var items []errx.ItemError
+	for i, item := range e.Items {
+		itemErr := marshalableDetails(item.Error)
+		if itemErr == item.Error {
+			continue
+		}
+		if items == nil {
+			items = append([]errx.ItemError(nil), e.Items...)
+		}
+		items[i].Error = itemErr
+	}
+
+	if details == nil && items == nil {
+		return e
+	}
+
+	newErr := *e
+	if details != nil {
+		newErr.Details = details
+	}
+	if items != nil {
+		newErr.Items = items
+	}
+	return &newErr
+}
+
+// StatusClientClosedRequest is the non-standard status written for Canceled errors.
+// The client has usually gone away, so the status is mostly seen in logs and metrics,
+// where it is not counted as a server error.
+const StatusClientClosedRequest = 499
+
+// StatusText returns the text of the HTTP status, including StatusClientClosedRequest.
+func StatusText(status int) string {
+	if status == StatusClientClosedRequest {
+		return "Client Closed Request"
+	}
+	return http.StatusText(status)
 }
 
-func httpStatusCode(err error) int {
+// HTTPStatus returns the HTTP status code errto.HTTP writes for the error.
+// Errors that are not ErrorX result in 500 Internal Server Error.
+func HTTPStatus(err error) int {
 	if err == nil {
 		return http.StatusOK
 	}
@@ -56,6 +160,20 @@ func httpStatusCode(err error) int {
 			return http.StatusNotFound
 		case errx.Conflict:
 			return http.StatusConflict
+		case errx.Timeout:
+			return http.StatusGatewayTimeout
+		case errx.Canceled:
+			return StatusClientClosedRequest
+		case errx.Unavailable:
+			return http.StatusServiceUnavailable
+		case errx.RateLimited:
+			return http.StatusTooManyRequests
+		case errx.PayloadTooLarge:
+			return http.StatusRequestEntityTooLarge
+		case errx.PreconditionFailed:
+			return http.StatusPreconditionFailed
+		case errx.UnsupportedMediaType:
+			return http.StatusUnsupportedMediaType
 		case errx.Internal:
 			return http.StatusInternalServerError
 		}
@@ -0,0 +1,206 @@
+package errto_test
+
+import (
+	"context"
+	"encoding/json"
+	"go-start-template/pkg/errx"
+	"go-start-template/pkg/errx/errto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestHTTP(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	errto.HTTP(w, errx.ErrNotFound.WithDetail("id", "42"))
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	require.JSONEq(t, `{
+		"message": "Resource not found",
+		"code": "NOT_FOUND",
+		"details": {"id": "42"}
+	}`, w.Body.String())
+}
+
+func TestHTTPProblemDetails(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/my-model/42?expand=true", nil)
+
+	errto.HTTP(w, errx.ErrNotFound.WithDetail("id", "42"),
+		errto.WithProblemDetails("https://example.com/errors/"),
+		errto.WithRequest(r),
+	)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, errto.ContentTypeProblem, w.Header().Get("Content-Type"))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, map[string]any{
+		"type":     "https://example.com/errors/NOT_FOUND",
+		"title":    "Not Found",
+		"status":   float64(http.StatusNotFound),
+		"detail":   "Resource not found",
+		"instance": "/api/v1/my-model/42?expand=true",
+		"code":     "NOT_FOUND",
+		"details":  map[string]any{"id": "42"},
+	}, body)
+}
+
+func TestHTTPHideInternalDetails(t *testing.T) {
+	err := errx.ErrConflict.
+		WithDetail("id", "42").
+		WithInternalDetail("constraint", "my_model_name_key")
+
+	w := httptest.NewRecorder()
+	errto.HTTP(w, err)
+	require.JSONEq(t, `{
+		"message": "Resource already exists",
+		"code": "ALREADY_EXISTS",
+		"details": {"id": "42", "constraint": "my_model_name_key"}
+	}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	errto.HTTP(w, err, errto.HideInternalDetails())
+	require.JSONEq(t, `{
+		"message": "Resource already exists",
+		"code": "ALREADY_EXISTS",
+		"details": {"id": "42"}
+	}`, w.Body.String())
+}
+
+type localizerFunc func(e *errx.ErrorX, acceptLanguage string) (string, bool)
+
+func (f localizerFunc) Localize(e *errx.ErrorX, acceptLanguage string) (string, bool) {
+	return f(e, acceptLanguage)
+}
+
+func TestHTTPWithLocalizer(t *testing.T) {
+	localizer := localizerFunc(func(e *errx.ErrorX, acceptLanguage string) (string, bool) {
+		if acceptLanguage != "uz" {
+			return "", false
+		}
+		return "Resurs topilmadi", true
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/my-model/42", nil)
+	r.Header.Set("Accept-Language", "uz")
+	w := httptest.NewRecorder()
+	errto.HTTP(w, errx.ErrNotFound, errto.WithLocalizer(localizer), errto.WithRequest(r))
+	require.JSONEq(t, `{"message": "Resurs topilmadi", "code": "NOT_FOUND"}`, w.Body.String())
+
+	r.Header.Set("Accept-Language", "de")
+	w = httptest.NewRecorder()
+	errto.HTTP(w, errx.ErrNotFound, errto.WithLocalizer(localizer), errto.WithRequest(r))
+	require.JSONEq(t, `{"message": "Resource not found", "code": "NOT_FOUND"}`, w.Body.String())
+}
+
+func TestHTTPJoin(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	errto.HTTP(w, errx.Join(nil, errx.ErrValidation.WithDetail("name", "required")))
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.JSONEq(t, `{
+		"message": "Multiple errors occurred",
+		"code": "MULTIPLE_ERRORS",
+		"items": [
+			{"index": 1, "error": {"message": "Validation error", "code": "VALIDATION", "details": {"name": "required"}}}
+		]
+	}`, w.Body.String())
+}
+
+func TestHTTPTypedDetails(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	errto.HTTP(w, errx.ErrValidation.
+		WithDetail("ids", []int{1, 2}).
+		WithDetail("range", map[string]int{"min": 1, "max": 10}))
+
+	require.JSONEq(t, `{
+		"message": "Validation error",
+		"code": "VALIDATION",
+		"details": {"ids": [1, 2], "range": {"min": 1, "max": 10}}
+	}`, w.Body.String())
+}
+
+func TestHTTPUnmarshalableDetails(t *testing.T) {
+	type node struct {
+		Next *node
+	}
+	cyclic := &node{}
+	cyclic.Next = cyclic
+
+	err := errx.Join(errx.ErrValidation.
+		WithDetail("name", "required").
+		WithDetail("chan", make(chan int)).
+		WithDetail("func", func() {}).
+		WithInternalDetail("cyclic", cyclic))
+
+	for _, opts := range [][]errto.Option{nil, {errto.WithProblemDetails("")}} {
+		w := httptest.NewRecorder()
+		errto.HTTP(w, err, opts...)
+
+		var body struct {
+			Code  string `json:"code"`
+			Items []struct {
+				Error struct {
+					Details map[string]any `json:"details"`
+				} `json:"error"`
+			} `json:"items"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body), w.Body.String())
+		require.Equal(t, errx.CodeMultiple, body.Code)
+		require.Len(t, body.Items, 1)
+
+		details := body.Items[0].Error.Details
+		require.Equal(t, "required", details["name"])
+		require.IsType(t, "", details["chan"])
+		require.IsType(t, "", details["func"])
+		require.IsType(t, "", details["cyclic"])
+	}
+}
+
+func TestHTTPWithRequestID(t *testing.T) {
+	w := httptest.NewRecorder()
+	errto.HTTP(w, errx.ErrNotFound, errto.WithRequestID("req-1"))
+
+	require.JSONEq(t, `{
+		"message": "Resource not found",
+		"code": "NOT_FOUND",
+		"request_id": "req-1"
+	}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	errto.HTTP(w, errx.ErrNotFound, errto.WithRequestID("req-1"), errto.WithProblemDetails(""))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, "req-1", body["request_id"])
+
+	// The request ID of the failed service is kept as an internal detail by the calling service
+	err := errx.FromHTTPResponse(w.Result())
+
+	var e *errx.ErrorX
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, "req-1", e.Details["request_id"])
+	require.True(t, e.IsInternalDetail("request_id"))
+}
+
+func TestHTTPCanceled(t *testing.T) {
+	w := httptest.NewRecorder()
+	errto.HTTP(w, context.Canceled, errto.WithProblemDetails(""))
+
+	require.Equal(t, errto.StatusClientClosedRequest, w.Code)
+	require.Equal(t, codes.Canceled, errto.GRPCCode(errx.Wrap(context.Canceled)))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, "Client Closed Request", body["title"])
+	require.Equal(t, errx.CodeCanceled, body["code"])
+}
@@ -0,0 +1,114 @@
+package errto
+
+import (
+	"go-start-template/pkg/errx"
+	"net/http"
+)
+
+// Option configures how errors are written by errto.HTTP and errto.GRPC.
+type Option func(*options)
+
+type options struct {
+	request        *http.Request
+	problemDetails bool
+	problemTypeURI string
+	hideInternal   bool
+	localizer      Localizer
+	observer       Observer
+	route          string
+	requestID      string
+}
+
+func newOptions(opts []Option) *options {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithRequest passes the request the error response is written for.
+// The request URI is used as the "instance" member of problem details.
+func WithRequest(r *http.Request) Option {
+	return func(o *options) {
+		o.request = r
+	}
+}
+
+// WithProblemDetails makes errto.HTTP write RFC 7807 problem details
+// with "application/problem+json" content type instead of the default body.
+//
+// The error code is appended to the typeURI to build the "type" member,
+// e.g. "https://example.com/errors/" results in "https://example.com/errors/NOT_FOUND".
+// If typeURI is empty, "about:blank" is used as the type.
+func WithProblemDetails(typeURI string) Option {
+	return func(o *options) {
+		o.problemDetails = true
+		o.problemTypeURI = typeURI
+	}
+}
+
+// HideInternalDetails strips internal details (see errx.ErrorX.WithInternalDetail)
+// from HTTP and gRPC responses. The option is intended for production mode,
+// where driver errors and other internals must not be exposed to clients.
+func HideInternalDetails() Option {
+	return func(o *options) {
+		o.hideInternal = true
+	}
+}
+
+// Localizer translates messages of errors to the language requested by the client.
+// See the i18n package for the catalog of messages loaded from YAML files.
+type Localizer interface {
+	// Localize returns the message of the error in the language best matching
+	// the Accept-Language header value, or false if there is no such message.
+	Localize(e *errx.ErrorX, acceptLanguage string) (string, bool)
+}
+
+// WithLocalizer makes errto.HTTP write the message of the error in the language
+// of the Accept-Language header of the request passed with WithRequest option.
+// The default message of the error is written if the localizer has no message for it.
+func WithLocalizer(l Localizer) Option {
+	return func(o *options) {
+		o.localizer = l
+	}
+}
+
+// Observer is notified about every error written by errto.HTTP and errto.GRPC.
+// See the errmetrics package for the observer exporting Prometheus metrics.
+type Observer interface {
+	// ObserveError is called with the transport ("http" or "grpc"), the route
+	// passed with WithRoute option and the error written to the response.
+	ObserveError(transport, route string, e *errx.ErrorX)
+}
+
+// WithObserver makes errto.HTTP and errto.GRPC notify the observer about the written error.
+func WithObserver(observer Observer) Option {
+	return func(o *options) {
+		o.observer = observer
+	}
+}
+
+// WithRoute passes the route the error is written for to the observer.
+// Use the route pattern for HTTP (e.g. "/api/v1/my-model/:id") and the full method for gRPC,
+// so the number of distinct routes stays low.
+func WithRoute(route string) Option {
+	return func(o *options) {
+		o.route = route
+	}
+}
+
+// WithRequestID makes errto.HTTP include the ID of the request in the body as the "request_id" member,
+// so clients can report it and the error can be found in the logs.
+func WithRequestID(id string) Option {
+	return func(o *options) {
+		o.requestID = id
+	}
+}
+
+// observe notifies the observer about the error, if the observer is set.
+func (o *options) observe(transport string, e *errx.ErrorX) {
+	if o.observer != nil {
+		o.observer.ObserveError(transport, o.route, e)
+	}
+}
@@ -0,0 +1,51 @@
+package errto
+
+import (
+	"go-start-template/pkg/errx"
+	"strings"
+)
+
+// ContentTypeProblem is the content type of RFC 7807 problem details.
+const ContentTypeProblem = "application/problem+json"
+
+// problemDetails is the RFC 7807 representation of an ErrorX.
+// The code, details, field violations, item errors and retryable flag of the error
+// and the ID of the request are added as extension members.
+type problemDetails struct {
+	Type       string                `json:"type"`
+	Title      string                `json:"title"`
+	Status     int                   `json:"status"`
+	Detail     string                `json:"detail,omitempty"`
+	Instance   string                `json:"instance,omitempty"`
+	Code       string                `json:"code"`
+	Details    map[string]any        `json:"details,omitempty"`
+	Violations []errx.FieldViolation `json:"violations,omitempty"`
+	Items      []errx.ItemError      `json:"items,omitempty"`
+	Retryable  *bool                 `json:"retryable,omitempty"`
+	RequestID  string                `json:"request_id,omitempty"`
+}
+
+func newProblemDetails(e *errx.ErrorX, status int, o *options) problemDetails {
+	problem := problemDetails{
+		Type:       "about:blank",
+		Title:      StatusText(status),
+		Status:     status,
+		Detail:     e.Message,
+		Code:       e.Code,
+		Details:    e.Details,
+		Violations: e.Violations,
+		Items:      e.Items,
+		Retryable:  e.Retryable,
+		RequestID:  o.requestID,
+	}
+
+	if o.problemTypeURI != "" {
+		problem.Type = strings.TrimSuffix(o.problemTypeURI, "/") + "/" + e.Code
+	}
+
+	if o.request != nil {
+		problem.Instance = o.request.URL.RequestURI()
+	}
+
+	return problem
+}
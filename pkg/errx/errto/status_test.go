@@ -0,0 +1,55 @@
+package errto_test
+
+import (
+	"errors"
+	"go-start-template/pkg/errx"
+	"go-start-template/pkg/errx/errto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusMapping(t *testing.T) {
+	tests := []struct {
+		err        *errx.ErrorX
+		httpStatus int
+		grpcCode   codes.Code
+
+		// The type of a plain gRPC status with the code, sent by services not using errto
+		plainType errx.Type
+	}{
+		{errx.ErrRateLimited, http.StatusTooManyRequests, codes.ResourceExhausted, errx.RateLimited},
+		{errx.ErrPayloadTooLarge, http.StatusRequestEntityTooLarge, codes.InvalidArgument, errx.Validation},
+		{errx.ErrPreconditionFailed, http.StatusPreconditionFailed, codes.FailedPrecondition, errx.PreconditionFailed},
+		{errx.ErrUnsupportedMediaType, http.StatusUnsupportedMediaType, codes.InvalidArgument, errx.Validation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Code, func(t *testing.T) {
+			require.Equal(t, tt.httpStatus, errto.HTTPStatus(tt.err))
+			require.Equal(t, tt.grpcCode, errto.GRPCCode(tt.err))
+
+			// The type is carried in the status details, so the gRPC round trip is not lossy
+			var e *errx.ErrorX
+			grpcErr := errto.GRPC(tt.err)
+			require.Equal(t, tt.grpcCode, status.Code(grpcErr))
+			require.True(t, errors.As(errx.Wrap(grpcErr), &e))
+			require.Equal(t, tt.err.Type, e.Type)
+			require.Equal(t, tt.err.Code, e.Code)
+
+			w := httptest.NewRecorder()
+			errto.HTTP(w, tt.err)
+			require.True(t, errors.As(errx.FromHTTPResponse(w.Result()), &e))
+			require.Equal(t, tt.err.Type, e.Type)
+			require.True(t, errors.Is(e, tt.err))
+
+			// Without the details the type is derived from the code
+			require.True(t, errors.As(errx.Wrap(status.Error(tt.grpcCode, "plain")), &e))
+			require.Equal(t, tt.plainType, e.Type)
+		})
+	}
+}
@@ -12,26 +12,39 @@ package errx
 
 import (
 	"errors"
+	"sort"
 )
 
 // Compile-time check to ensure ErrorX implements the error interface.
 var _ error = (*ErrorX)(nil)
 
 // Type defines the different categories of errors that can be represented by an ErrorX.
+// The type value is transferred between services in gRPC error details,
+// so new types must be appended to the end of the list.
 type Type int8
 
 const (
-	Internal       Type = iota // Internal errors indicate unexpected issues within the application.
-	Authentication             // Authentication errors represent issues with user authentication.
-	Forbidden                  // Forbidden errors indicate that the user is not allowed to perform the action.
-	Validation                 // Validation errors occur when user input does not meet expected criteria.
-	NotFound                   // NotFound errors are returned when a requested resource cannot be located.
-	Conflict                   // Conflict errors occur when a resource already exists.
+	Internal             Type = iota // Internal errors indicate unexpected issues within the application.
+	Authentication                   // Authentication errors represent issues with user authentication.
+	Forbidden                        // Forbidden errors indicate that the user is not allowed to perform the action.
+	Validation                       // Validation errors occur when user input does not meet expected criteria.
+	NotFound                         // NotFound errors are returned when a requested resource cannot be located.
+	Conflict                         // Conflict errors occur when a resource already exists.
+	Timeout                          // Timeout errors occur when an operation does not complete in time.
+	Unavailable                      // Unavailable errors indicate a temporary condition, the operation may succeed if retried.
+	RateLimited                      // RateLimited errors occur when the client has sent too many requests.
+	PayloadTooLarge                  // PayloadTooLarge errors occur when the request payload exceeds the allowed size.
+	PreconditionFailed               // PreconditionFailed errors occur when the system is not in a state required for the operation.
+	UnsupportedMediaType             // UnsupportedMediaType errors occur when the request payload format is not supported.
+	Canceled                         // Canceled errors occur when the caller cancels the operation, e.g. the client disconnects.
 )
 
 // New creates a new ErrorX with the given type, message, and code.
 // ErrorX instances are used to provide detailed error information that can be easily
 // converted into structured error responses for APIs.
+//
+// New has no side effects, use NewSentinel for package level declarations of errors
+// that should be listed in the registry of errors.
 func New(errType Type, msg string, code string) *ErrorX {
 	return &ErrorX{
 		Message: msg,
@@ -41,6 +54,18 @@ func New(errType Type, msg string, code string) *ErrorX {
 	}
 }
 
+// NewSentinel creates a new ErrorX like New and records it in the registry of errors
+// (see Registered), so the error is documented in the error catalog and is matched
+// by errors received from other services with the same code.
+// It is intended for package level declarations of errors, not for creating errors on every call:
+//
+//	var ErrModelNotFound = errx.NewSentinel(errx.NotFound, "Model not found", "MODEL_NOT_FOUND")
+func NewSentinel(errType Type, msg string, code string) *ErrorX {
+	e := New(errType, msg, code)
+	register(e)
+	return e
+}
+
 // ErrorX represents a structured error that can be used within an application.
 // It is serializable to JSON, making it suitable for use in API responses and
 // can also be converted to gRPC status codes.
@@ -57,13 +82,35 @@ type ErrorX struct { // nolint
 
 	// Details is an optional map of additional details about the error,
 	// which can be useful for debugging or providing more context in API responses.
-	Details map[string]string `json:"details,omitempty"`
+	// Values must be serializable to JSON (strings, numbers, booleans, slices, maps or structs).
+	// Details of the keys listed in internal are intended for logs and debugging only,
+	// see WithInternalDetail.
+	Details map[string]any `json:"details,omitempty"`
+
+	// Violations lists the fields of the request that failed validation.
+	Violations []FieldViolation `json:"violations,omitempty"`
+
+	// Retryable is set when the error is explicitly marked as retryable or not (see WithRetryable).
+	// If it is nil, the retryability is derived from the type of the error, see IsRetryable.
+	Retryable *bool `json:"retryable,omitempty"`
+
+	// Items are the errors of the failed items of a batch operation, see Join.
+	Items []ItemError `json:"items,omitempty"`
+
+	// internal is the set of keys of internal details.
+	internal map[string]struct{}
 
 	// origin is used to support error comparison using errors.Is.
 	origin error
 
-	// trace is used to store the stack trace of the error.
+	// cause is the original error converted to the ErrorX by Wrap.
+	cause error
+
+	// trace is used to store the chain of Wrap call sites of the error.
 	trace string
+
+	// stack is the program counters of the stack captured when the error was created.
+	stack []uintptr
 }
 
 // Error implements the error interface for ErrorX.
@@ -71,7 +118,8 @@ func (e ErrorX) Error() string {
 	return e.Message
 }
 
-// Trace returns the stack trace of the error.
+// Trace returns the chain of Wrap call sites of the error.
+// See StackTrace for the full stack captured when the error was created.
 func (e *ErrorX) Trace() string {
 	return e.trace
 }
@@ -82,12 +130,12 @@ func (e *ErrorX) Trace() string {
 //
 // Example usage:
 //
-//		var NotFoundErr = errx.New(errx.NotFound, "resource not found", errx.CodeNotFound)
+//		var NotFoundErr = errx.NewSentinel(errx.NotFound, "resource not found", errx.CodeNotFound)
 //
 //		err := repo.FindByID(123)
 //		if err != nil {
 //			return NotFoundErr.
-//				WithDetail("user_id", "123").
+//				WithDetail("user_id", 123).
 //				WithDetail("table", "users").
 //	            ... add arbitrary details here ...
 //		}
@@ -97,30 +145,126 @@ func (e *ErrorX) Trace() string {
 //	if errors.Is(err, NotFoundErr) {
 //		// handle not found error
 //	}
-func (e *ErrorX) WithDetail(key string, value string) *ErrorX {
-	newErr := *e
-	if newErr.Details == nil {
-		newErr.Details = make(map[string]string)
-	}
+//
+// The value is serialized as is in HTTP responses, so numbers, lists and nested objects
+// are not stringified. The value must not be modified after it is added.
+func (e *ErrorX) WithDetail(key string, value any) *ErrorX {
+	newErr := e.clone()
 	newErr.Details[key] = value
-	return &newErr
+	delete(newErr.internal, key)
+	newErr.captureStack()
+	return newErr
+}
+
+// WithInternalDetail returns a copy of the ErrorX with an added internal detail.
+// Internal details are logged like any other detail, but they are stripped from HTTP and gRPC
+// responses when errto.HideInternalDetails option is used (e.g. in production mode).
+// Use internal details for information clients must not see, like driver errors,
+// SQL statements or names of database constraints:
+//
+//	return errx.ErrInternal.WithInternalDetail("error", err.Error())
+func (e *ErrorX) WithInternalDetail(key string, value any) *ErrorX {
+	newErr := e.clone()
+	newErr.Details[key] = value
+	newErr.internal[key] = struct{}{}
+	newErr.captureStack()
+	return newErr
+}
+
+// IsInternalDetail reports whether the detail with the given key is internal.
+func (e *ErrorX) IsInternalDetail(key string) bool {
+	_, ok := e.internal[key]
+	return ok
+}
+
+// InternalDetailKeys returns the keys of internal details of the error.
+func (e *ErrorX) InternalDetailKeys() []string {
+	keys := make([]string, 0, len(e.internal))
+	for k := range e.internal {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// WithoutInternalDetails returns a copy of the ErrorX with internal details removed,
+// including internal details of the item errors.
+func (e *ErrorX) WithoutInternalDetails() *ErrorX {
+	newErr := e.clone()
+	for k := range newErr.internal {
+		delete(newErr.Details, k)
+	}
+	newErr.internal = make(map[string]struct{})
+	for i, item := range newErr.Items {
+		newErr.Items[i].Error = item.Error.WithoutInternalDetails()
+	}
+	return newErr
 }
 
 // WithCode returns a copy of the ErrorX with the given code.
 func (e *ErrorX) WithCode(code string) *ErrorX {
-	newErr := *e
+	newErr := e.clone()
 	newErr.Code = code
+	newErr.captureStack()
+	return newErr
+}
+
+// WithMessage returns a copy of the ErrorX with the given message.
+// The copy is still matched by errors.Is against the original error.
+func (e *ErrorX) WithMessage(msg string) *ErrorX {
+	newErr := e.clone()
+	newErr.Message = msg
+	newErr.captureStack()
+	return newErr
+}
+
+// clone returns a copy of the ErrorX that does not share details with the original.
+func (e *ErrorX) clone() *ErrorX {
+	newErr := *e
+	newErr.Details = make(map[string]any, len(e.Details)+1)
+	for k, v := range e.Details {
+		newErr.Details[k] = v
+	}
+	newErr.Violations = append([]FieldViolation(nil), e.Violations...)
+	newErr.Items = append([]ItemError(nil), e.Items...)
+	newErr.internal = make(map[string]struct{}, len(e.internal))
+	for k := range e.internal {
+		newErr.internal[k] = struct{}{}
+	}
 	return &newErr
 }
 
 // Is implements the errors.Is interface for ErrorX.
-// It allows comparison of two ErrorX instances, returning true if they share the same origin.
+// It allows comparison of two ErrorX instances, returning true if they share the same origin
+// or if any of the item errors (see Join) matches the target.
+// The cause of the error is compared by errors.Is separately through Unwrap.
 func (e *ErrorX) Is(target error) bool {
 	t, ok := target.(*ErrorX)
 	if !ok {
 		return false
 	}
-	return e.origin == t.origin
+	if e.origin == t.origin {
+		return true
+	}
+	for _, item := range e.Items {
+		if errors.Is(item.Error, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns the original error converted to the ErrorX by Wrap, or nil if there is none.
+// It allows inspecting the original error with errors.As after wrapping:
+//
+//	err := errx.Wrap(pgErr)
+//
+//	var pgErr *pgconn.PgError
+//	if errors.As(err, &pgErr) {
+//		// handle the original error
+//	}
+func (e *ErrorX) Unwrap() error {
+	return e.cause
 }
 
 // GetCode returns the error code of an ErrorX.
@@ -0,0 +1,180 @@
+package errx
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// statusClientClosedRequest is the non-standard status of requests canceled by the client,
+// it is written by errto.HTTP for Canceled errors.
+const statusClientClosedRequest = 499
+
+// maxErrorBodySize limits the size of the response body read by FromHTTPResponse.
+const maxErrorBodySize = 1 << 20
+
+// httpBody is the body written by errto.HTTP in both default and problem details formats.
+type httpBody struct {
+	Message    string           `json:"message"`
+	Code       string           `json:"code"`
+	Details    map[string]any   `json:"details"`
+	Violations []FieldViolation `json:"violations"`
+	Items      []httpItem       `json:"items"`
+	Retryable  *bool            `json:"retryable"`
+	RequestID  string           `json:"request_id"`
+
+	// Members of RFC 7807 problem details
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+type httpItem struct {
+	Index int      `json:"index"`
+	Error httpBody `json:"error"`
+}
+
+// httpStatusTypes maps HTTP status codes to the types of errors, reversing errto.HTTP.
+var httpStatusTypes = map[int]Type{
+	http.StatusUnauthorized:          Authentication,
+	http.StatusForbidden:             Forbidden,
+	http.StatusBadRequest:            Validation,
+	http.StatusUnprocessableEntity:   Validation,
+	http.StatusNotFound:              NotFound,
+	http.StatusConflict:              Conflict,
+	http.StatusRequestTimeout:        Timeout,
+	http.StatusGatewayTimeout:        Timeout,
+	http.StatusServiceUnavailable:    Unavailable,
+	http.StatusBadGateway:            Unavailable,
+	http.StatusTooManyRequests:       RateLimited,
+	http.StatusRequestEntityTooLarge: PayloadTooLarge,
+	http.StatusPreconditionFailed:    PreconditionFailed,
+	http.StatusUnsupportedMediaType:  UnsupportedMediaType,
+	statusClientClosedRequest:        Canceled,
+}
+
+// FromHTTPResponse converts an error response of a service into an ErrorX.
+// It returns nil if the status code of the response is not an error (below 400).
+//
+// The body written by errto.HTTP, either in the default or the problem details format,
+// is decoded preserving the message, code, details, field violations, item errors and retryable flag.
+// If the code is registered (see Registered), the error matches the registered error with errors.Is,
+// otherwise the type of the error is derived from the status code.
+// The ID of the request the service failed, if any, is an internal detail "request_id".
+// Responses that are not written by errto.HTTP are converted to the default error
+// of the status code with the body as an internal detail.
+//
+// The body is read but not closed:
+//
+//	resp, err := client.Do(req)
+//	if err != nil {
+//		return errx.Wrap(err)
+//	}
+//	defer resp.Body.Close()
+//
+//	if err := errx.FromHTTPResponse(resp); err != nil {
+//		return err
+//	}
+func FromHTTPResponse(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	errType, ok := httpStatusTypes[resp.StatusCode]
+	if !ok {
+		errType = Internal
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return defaultError(errType).
+			WithInternalDetail("status", resp.Status).
+			WithInternalDetail("error", err.Error())
+	}
+
+	var body httpBody
+	if err := json.Unmarshal(data, &body); err != nil || body.Code == "" {
+		return defaultError(errType).
+			WithInternalDetail("status", resp.Status).
+			WithInternalDetail("error", string(data))
+	}
+
+	e := fromHTTPBody(body, errType)
+	if body.RequestID != "" {
+		e = e.WithInternalDetail("request_id", body.RequestID)
+	}
+	e.captureStack()
+	return e
+}
+
+func fromHTTPBody(body httpBody, errType Type) *ErrorX {
+	msg := body.Message
+	if msg == "" {
+		msg = body.Detail
+	}
+	if msg == "" {
+		msg = body.Title
+	}
+
+	e := registered(body.Code)
+	if e == nil {
+		e = New(errType, msg, body.Code)
+	} else {
+		e = e.clone()
+		e.Message = msg
+		e.Type = errType
+	}
+
+	e.Retryable = body.Retryable
+	for k, v := range body.Details {
+		e = e.WithDetail(k, v)
+	}
+	if len(body.Violations) > 0 {
+		e = e.WithFieldViolations(body.Violations...)
+	}
+	for _, item := range body.Items {
+		itemType := Internal
+		if r := registered(item.Error.Code); r != nil {
+			itemType = r.Type
+		}
+		e.Items = append(e.Items, ItemError{
+			Index: item.Index,
+			Error: fromHTTPBody(item.Error, itemType),
+		})
+	}
+	return e
+}
+
+// defaultError returns the default error of the type.
+func defaultError(t Type) *ErrorX {
+	switch t {
+	case Authentication:
+		return ErrAuthentication
+	case Forbidden:
+		return ErrForbidden
+	case Validation:
+		return ErrValidation
+	case NotFound:
+		return ErrNotFound
+	case Conflict:
+		return ErrConflict
+	case Timeout:
+		return ErrTimeout
+	case Unavailable:
+		return ErrUnavailable
+	case RateLimited:
+		return ErrRateLimited
+	case PayloadTooLarge:
+		return ErrPayloadTooLarge
+	case PreconditionFailed:
+		return ErrPreconditionFailed
+	case UnsupportedMediaType:
+		return ErrUnsupportedMediaType
+	case Canceled:
+		return ErrCanceled
+	case Internal:
+		return ErrInternal
+	}
+
+	// Types unknown to the application, e.g. received from a newer service
+	return ErrInternal
+}
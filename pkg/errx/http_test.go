@@ -0,0 +1,71 @@
+package errx_test
+
+import (
+	"errors"
+	"go-start-template/pkg/errx"
+	"go-start-template/pkg/errx/errto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromHTTPResponse(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []errto.Option
+	}{
+		{"default format", nil},
+		{"problem details", []errto.Option{errto.WithProblemDetails("")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			errto.HTTP(w, errx.ErrNotFound.WithDetail("id", "42"), tt.opts...)
+
+			err := errx.FromHTTPResponse(w.Result())
+
+			var e *errx.ErrorX
+			require.True(t, errors.As(err, &e))
+			require.True(t, errors.Is(err, errx.ErrNotFound))
+			require.Equal(t, errx.NotFound, e.Type)
+			require.Equal(t, errx.ErrNotFound.Message, e.Message)
+			require.Equal(t, map[string]any{"id": "42"}, e.Details)
+		})
+	}
+}
+
+func TestFromHTTPResponseJoin(t *testing.T) {
+	w := httptest.NewRecorder()
+	errto.HTTP(w, errx.Join(nil, errx.ErrConflict))
+
+	err := errx.FromHTTPResponse(w.Result())
+
+	var e *errx.ErrorX
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, errx.Conflict, e.Type)
+	require.True(t, errors.Is(err, errx.ErrConflict))
+	require.Equal(t, 1, e.Items[0].Index)
+}
+
+func TestFromHTTPResponseUnknownBody(t *testing.T) {
+	w := httptest.NewRecorder()
+	http.Error(w, "upstream is down", http.StatusBadGateway)
+
+	err := errx.FromHTTPResponse(w.Result())
+
+	var e *errx.ErrorX
+	require.True(t, errors.As(err, &e))
+	require.True(t, errors.Is(err, errx.ErrUnavailable))
+	require.True(t, e.IsInternalDetail("error"))
+
+	w = httptest.NewRecorder()
+	http.Error(w, "panic", http.StatusInternalServerError)
+	require.True(t, errors.Is(errx.FromHTTPResponse(w.Result()), errx.ErrInternal))
+
+	w = httptest.NewRecorder()
+	w.WriteHeader(http.StatusNoContent)
+	require.NoError(t, errx.FromHTTPResponse(w.Result()))
+}
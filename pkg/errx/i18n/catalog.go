@@ -0,0 +1,143 @@
+// Package i18n provides a catalog of localized messages of errx errors.
+//
+// Messages are keyed by error code and are text/template templates executed
+// with the details of the error, so details can be interpolated into messages:
+//
+//	NOT_FOUND: "Resource {{.id}} not found"
+//
+// The catalog implements errto.Localizer and is intended to be passed to errto.HTTP
+// with errto.WithLocalizer option, which picks the language from the Accept-Language header.
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	"go-start-template/pkg/errx"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// Catalog is a set of localized error messages keyed by language and error code.
+// It is safe for concurrent use.
+type Catalog struct {
+	mu       sync.RWMutex
+	tags     []language.Tag
+	matcher  language.Matcher
+	messages map[language.Tag]map[string]*template.Template
+}
+
+// NewCatalog creates an empty catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{
+		messages: make(map[language.Tag]map[string]*template.Template),
+	}
+}
+
+// LoadDir creates a catalog from YAML files of the directory.
+// Every file is named after the language of its messages (e.g. "uz.yaml", "ru.yaml")
+// and contains a map of error codes to message templates.
+func LoadDir(dir string) (*Catalog, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewCatalog()
+	for _, file := range files {
+		lang := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		if err := c.LoadFile(lang, file); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// LoadFile adds the messages of the YAML file to the catalog for the given language.
+func (c *Catalog) LoadFile(lang, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read messages from %s: %w", path, err)
+	}
+
+	var messages map[string]string
+	if err := yaml.Unmarshal(data, &messages); err != nil {
+		return fmt.Errorf("failed to parse messages from %s: %w", path, err)
+	}
+
+	for code, msg := range messages {
+		if err := c.Add(lang, code, msg); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Add adds the message template for the error code in the given language.
+// The template is executed with the details of the error. If a detail used
+// by the template is missing, the default message of the error is used instead.
+func (c *Catalog) Add(lang, code, msg string) error {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return fmt.Errorf("invalid language %q: %w", lang, err)
+	}
+
+	tmpl, err := template.New(code).Option("missingkey=error").Parse(msg)
+	if err != nil {
+		return fmt.Errorf("invalid message of %s in %q: %w", code, lang, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.messages[tag]; !ok {
+		c.messages[tag] = make(map[string]*template.Template)
+		c.tags = append(c.tags, tag)
+		c.matcher = language.NewMatcher(c.tags)
+	}
+	c.messages[tag][code] = tmpl
+	return nil
+}
+
+// Localize returns the message of the error in the language best matching
+// the Accept-Language header value. It returns false if none of the requested languages
+// is in the catalog or there is no message for the code of the error,
+// so the default message of the error should be used.
+func (c *Catalog) Localize(e *errx.ErrorX, acceptLanguage string) (string, bool) {
+	if acceptLanguage == "" {
+		return "", false
+	}
+
+	requested, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(requested) == 0 {
+		return "", false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.matcher == nil {
+		return "", false
+	}
+
+	_, index, confidence := c.matcher.Match(requested...)
+	if confidence == language.No {
+		return "", false
+	}
+
+	tmpl, ok := c.messages[c.tags[index]][e.Code]
+	if !ok {
+		return "", false
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, e.Details); err != nil {
+		return "", false
+	}
+	return buf.String(), true
+}
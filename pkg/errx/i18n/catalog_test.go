@@ -0,0 +1,53 @@
+package i18n_test
+
+import (
+	"go-start-template/pkg/errx"
+	"go-start-template/pkg/errx/i18n"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogLocalize(t *testing.T) {
+	c := i18n.NewCatalog()
+	require.NoError(t, c.Add("ru", errx.CodeNotFound, "Ресурс {{.id}} не найден"))
+	require.NoError(t, c.Add("uz", errx.CodeNotFound, "{{.id}} resurs topilmadi"))
+
+	err := errx.ErrNotFound.WithDetail("id", "42")
+
+	msg, ok := c.Localize(err, "uz-UZ,ru;q=0.9,en;q=0.8")
+	require.True(t, ok)
+	require.Equal(t, "42 resurs topilmadi", msg)
+
+	msg, ok = c.Localize(err, "ru")
+	require.True(t, ok)
+	require.Equal(t, "Ресурс 42 не найден", msg)
+
+	// Unknown language and missing message fall back to the default message
+	_, ok = c.Localize(err, "de")
+	require.False(t, ok)
+	_, ok = c.Localize(errx.ErrConflict, "ru")
+	require.False(t, ok)
+	_, ok = c.Localize(err, "")
+	require.False(t, ok)
+}
+
+func TestCatalogLocalizeMissingDetail(t *testing.T) {
+	c := i18n.NewCatalog()
+	require.NoError(t, c.Add("ru", errx.CodeNotFound, "Ресурс {{.id}} не найден"))
+
+	// The message is not rendered with "<no value>", the default message is used instead
+	_, ok := c.Localize(errx.ErrNotFound, "ru")
+	require.False(t, ok)
+	_, ok = c.Localize(errx.ErrNotFound.WithDetail("name", "test"), "ru")
+	require.False(t, ok)
+}
+
+func TestLoadDir(t *testing.T) {
+	c, err := i18n.LoadDir("../../../configs/i18n")
+	require.NoError(t, err)
+
+	msg, ok := c.Localize(errx.ErrNotFound, "ru-RU")
+	require.True(t, ok)
+	require.Equal(t, "Ресурс не найден", msg)
+}
@@ -0,0 +1,116 @@
+package errx
+
+// ItemError is the error of a single item of a batch operation, see Join.
+type ItemError struct {
+
+	// Index is the position of the failed item in the batch.
+	Index int `json:"index"`
+
+	// Error is the error of the item.
+	Error *ErrorX `json:"error"`
+}
+
+// Join returns an error aggregating the failures of a batch operation.
+// The position of an error in errs is used as the index of the failed item, and nil errors
+// (succeeded items), including nil *ErrorX values, are skipped. Join returns nil if all errors are nil.
+// Errors that are not ErrorX are converted with Wrap.
+//
+// The returned error is a copy of ErrMultiple with the item errors in Items
+// and the dominant type of the item errors (see DominantType), which determines
+// the HTTP status and gRPC code of the response. The joined error is retryable
+// if all item errors are retryable:
+//
+//	errs := make([]error, len(models))
+//	for i, model := range models {
+//		errs[i] = repo.Create(ctx, model)
+//	}
+//	return errx.Join(errs...)
+//
+// The joined error matches every item error with errors.Is.
+func Join(errs ...error) error {
+	var items []ItemError
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		e, ok := err.(*ErrorX)
+		if ok && e == nil {
+			continue
+		}
+		if !ok {
+			e = Wrap(err).(*ErrorX)
+		}
+		items = append(items, ItemError{Index: i, Error: e})
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	types := make([]Type, 0, len(items))
+	retryable := true
+	for _, item := range items {
+		types = append(types, item.Error.Type)
+		retryable = retryable && IsRetryable(item.Error)
+	}
+
+	e := ErrMultiple.clone()
+	e.Type = DominantType(types...)
+
+	// The dominant type does not determine the retryability of the items,
+	// so the joined error is marked when they differ
+	if retryable != retryableType(e.Type) {
+		e.Retryable = &retryable
+	}
+
+	e.Items = items
+	e.captureStack()
+	return e
+}
+
+// typePrecedence orders the types from the most to the least dominant in a response:
+// server side failures first, then the client errors that must be fixed before
+// any item can succeed, then the errors of single items.
+var typePrecedence = []Type{
+	Internal,
+	Unavailable,
+	Timeout,
+	Authentication,
+	Forbidden,
+	RateLimited,
+	PayloadTooLarge,
+	UnsupportedMediaType,
+	Validation,
+	PreconditionFailed,
+	Conflict,
+	NotFound,
+	Canceled,
+}
+
+// DominantType returns the type that represents all the given types in a response.
+// If all types are the same, the type is returned. Otherwise the type with the highest
+// precedence is returned: Internal, Unavailable, Timeout, Authentication, Forbidden,
+// RateLimited, PayloadTooLarge, UnsupportedMediaType, Validation, PreconditionFailed,
+// Conflict, NotFound, Canceled.
+func DominantType(types ...Type) Type {
+	if len(types) == 0 {
+		return Internal
+	}
+
+	same := true
+	seen := make(map[Type]bool, len(types))
+	for _, t := range types {
+		seen[t] = true
+		same = same && t == types[0]
+	}
+	if same {
+		return types[0]
+	}
+
+	for _, t := range typePrecedence {
+		if seen[t] {
+			return t
+		}
+	}
+	return Internal
+}
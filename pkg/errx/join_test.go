@@ -0,0 +1,49 @@
+package errx_test
+
+import (
+	"errors"
+	"go-start-template/pkg/errx"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoin(t *testing.T) {
+	require.Nil(t, errx.Join(nil, nil))
+
+	err := errx.Join(nil, errx.ErrNotFound, errx.ErrConflict, errors.New("connection reset"))
+
+	var e *errx.ErrorX
+	require.True(t, errors.As(err, &e))
+	require.True(t, errors.Is(err, errx.ErrMultiple))
+	require.True(t, errors.Is(err, errx.ErrConflict))
+	require.False(t, errors.Is(err, errx.ErrTimeout))
+	require.Equal(t, errx.Internal, e.Type)
+	require.Len(t, e.Items, 3)
+	require.Equal(t, 1, e.Items[0].Index)
+	require.Equal(t, 3, e.Items[2].Index)
+	require.Equal(t, errx.CodeInternal, e.Items[2].Error.Code)
+}
+
+func TestJoinSkipsTypedNil(t *testing.T) {
+	var notFound *errx.ErrorX
+	require.Nil(t, errx.Join(notFound, nil))
+
+	err := errx.Join(notFound, errx.ErrValidation.WithDetail("name", "required"))
+
+	var e *errx.ErrorX
+	require.True(t, errors.As(err, &e))
+	require.Len(t, e.Items, 1)
+	require.Equal(t, 1, e.Items[0].Index)
+	require.Equal(t, errx.Validation, e.Type)
+}
+
+func TestDominantType(t *testing.T) {
+	require.Equal(t, errx.NotFound, errx.DominantType(errx.NotFound, errx.NotFound))
+	require.Equal(t, errx.Conflict, errx.DominantType(errx.NotFound, errx.Conflict))
+	require.Equal(t, errx.Authentication, errx.DominantType(errx.Forbidden, errx.Authentication, errx.Validation))
+	require.Equal(t, errx.Forbidden, errx.DominantType(errx.RateLimited, errx.Forbidden))
+	require.Equal(t, errx.RateLimited, errx.DominantType(errx.Validation, errx.RateLimited, errx.NotFound))
+	require.Equal(t, errx.Validation, errx.DominantType(errx.NotFound, errx.Validation))
+	require.Equal(t, errx.Unavailable, errx.DominantType(errx.NotFound, errx.Timeout, errx.Unavailable))
+}
@@ -0,0 +1,121 @@
+package errx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+)
+
+// Compile-time check to ensure ErrorX implements the slog.LogValuer interface.
+var _ slog.LogValuer = (*ErrorX)(nil)
+
+var typeNames = map[Type]string{
+	Internal:             "internal",
+	Authentication:       "authentication",
+	Forbidden:            "forbidden",
+	Validation:           "validation",
+	NotFound:             "not_found",
+	Conflict:             "conflict",
+	Timeout:              "timeout",
+	Unavailable:          "unavailable",
+	RateLimited:          "rate_limited",
+	PayloadTooLarge:      "payload_too_large",
+	PreconditionFailed:   "precondition_failed",
+	UnsupportedMediaType: "unsupported_media_type",
+	Canceled:             "canceled",
+}
+
+// String returns the name of the type in snake case, e.g. "not_found".
+func (t Type) String() string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("type(%d)", t)
+}
+
+// LogValue implements the slog.LogValuer interface for ErrorX.
+// The error is logged as a group with the type, code, message, details, field violations,
+// item errors keyed by index, cause and trace of the error.
+// The stack is added for errors logged at error level (see LogLevel):
+//
+//	logger.Error("failed to create model", "error", err)
+func (e *ErrorX) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("type", e.Type.String()),
+		slog.String("code", e.Code),
+		slog.String("message", e.Message),
+	}
+
+	if IsRetryable(e) {
+		attrs = append(attrs, slog.Bool("retryable", true))
+	}
+	if len(e.Details) > 0 {
+		details := make([]slog.Attr, 0, len(e.Details))
+		for k, v := range e.Details {
+			details = append(details, slog.Any(k, v))
+		}
+		attrs = append(attrs, slog.Any("details", slog.GroupValue(details...)))
+	}
+	if len(e.Violations) > 0 {
+		attrs = append(attrs, slog.Any("violations", e.Violations))
+	}
+	if len(e.Items) > 0 {
+		items := make([]slog.Attr, 0, len(e.Items))
+		for _, item := range e.Items {
+			items = append(items, slog.Any(strconv.Itoa(item.Index), item.Error))
+		}
+		attrs = append(attrs, slog.Any("items", slog.GroupValue(items...)))
+	}
+	if e.cause != nil {
+		attrs = append(attrs, slog.String("cause", e.cause.Error()))
+	}
+	if e.trace != "" {
+		attrs = append(attrs, slog.String("trace", e.trace))
+	}
+	if levelOf(e.Type) == slog.LevelError && len(e.stack) > 0 {
+		attrs = append(attrs, slog.Any("stack", e.StackTrace()))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// LogLevel returns the level the error should be logged at, derived from its type.
+// Unexpected errors (Internal, Unavailable and errors that are not ErrorX) are logged at error level,
+// errors caused by exhausted time or capacity (Timeout, RateLimited) at warn level
+// and errors caused by the client (Validation, NotFound, ...) at info level.
+// Operations canceled by the caller (Canceled) are expected, e.g. when clients disconnect,
+// so they are logged at debug level.
+func LogLevel(err error) slog.Level {
+	var e *ErrorX
+	if !errors.As(err, &e) {
+		return slog.LevelError
+	}
+	return levelOf(e.Type)
+}
+
+func levelOf(t Type) slog.Level {
+	switch t {
+	case Internal, Unavailable:
+		return slog.LevelError
+	case Timeout, RateLimited:
+		return slog.LevelWarn
+	case Authentication, Forbidden, Validation, NotFound, Conflict,
+		PayloadTooLarge, PreconditionFailed, UnsupportedMediaType:
+		return slog.LevelInfo
+	case Canceled:
+		return slog.LevelDebug
+	}
+
+	// Types unknown to the application, e.g. received from a newer service
+	return slog.LevelInfo
+}
+
+// Log logs the error with the given message and attributes at the level returned by LogLevel.
+// The error is added to the record with the "error" key:
+//
+//	errx.Log(ctx, logger, "failed to create model", err, "model_id", id)
+func Log(ctx context.Context, logger *slog.Logger, msg string, err error, args ...any) {
+	logger.Log(ctx, LogLevel(err), msg, append(args, slog.Any("error", err))...)
+}
@@ -0,0 +1,48 @@
+package errx_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"go-start-template/pkg/errx"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogLevel(t *testing.T) {
+	require.Equal(t, slog.LevelError, errx.LogLevel(errors.New("unexpected")))
+	require.Equal(t, slog.LevelError, errx.LogLevel(errx.ErrInternal))
+	require.Equal(t, slog.LevelWarn, errx.LogLevel(errx.ErrTimeout))
+	require.Equal(t, slog.LevelInfo, errx.LogLevel(errx.ErrValidation))
+	require.Equal(t, slog.LevelDebug, errx.LogLevel(errx.Wrap(context.Canceled)))
+	require.Equal(t, slog.LevelWarn, errx.LogLevel(errx.ErrRateLimited))
+	require.Equal(t, slog.LevelInfo, errx.LogLevel(errx.ErrPayloadTooLarge))
+
+	// Types unknown to the application, e.g. received from a newer service
+	unknown := errx.ErrInternal.WithMessage("Unknown")
+	unknown.Type = errx.Type(100)
+	require.Equal(t, slog.LevelInfo, errx.LogLevel(unknown))
+}
+
+func TestLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	err := errx.Wrap(errx.ErrNotFound.WithDetail("id", "1"))
+	errx.Log(context.Background(), logger, "failed to find model", err, "table", "models")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	require.Equal(t, "INFO", record["level"])
+	require.Equal(t, "models", record["table"])
+
+	logged := record["error"].(map[string]any)
+	require.Equal(t, "not_found", logged["type"])
+	require.Equal(t, errx.CodeNotFound, logged["code"])
+	require.Equal(t, map[string]any{"id": "1"}, logged["details"])
+	require.NotEmpty(t, logged["trace"])
+	require.NotContains(t, logged, "stack")
+}
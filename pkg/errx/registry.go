@@ -0,0 +1,95 @@
+package errx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// registry is the list of errors declared with NewSentinel.
+// Errors are registered at package initialization and read on every decoded response,
+// so readers load an immutable snapshot without locking and writers replace it.
+var registry struct {
+	mu       sync.Mutex
+	snapshot atomic.Pointer[registrySnapshot]
+}
+
+type registrySnapshot struct {
+	errs   []*ErrorX
+	byCode map[string]*ErrorX
+}
+
+func register(e *ErrorX) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	next := &registrySnapshot{byCode: make(map[string]*ErrorX)}
+	if current := registry.snapshot.Load(); current != nil {
+		next.errs = append(next.errs, current.errs...)
+		for code, e := range current.byCode {
+			next.byCode[code] = e
+		}
+	}
+
+	next.errs = append(next.errs, e)
+	// The first error declared with the code is matched, duplicates are reported by CheckCodes
+	if _, ok := next.byCode[e.Code]; !ok {
+		next.byCode[e.Code] = e
+	}
+	registry.snapshot.Store(next)
+}
+
+// Registered returns the errors declared with NewSentinel sorted by code.
+// Only errors of packages linked into the binary are registered,
+// so the list is complete once the main package is initialized.
+func Registered() []*ErrorX {
+	snapshot := registry.snapshot.Load()
+	if snapshot == nil {
+		return nil
+	}
+
+	errs := make([]*ErrorX, len(snapshot.errs))
+	copy(errs, snapshot.errs)
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Code < errs[j].Code
+	})
+	return errs
+}
+
+// CheckCodes returns an error if several registered errors share the same code.
+// Codes are the identifiers clients rely on, so the check is intended to be run at startup:
+//
+//	if err := errx.CheckCodes(); err != nil {
+//		log.Fatal(err)
+//	}
+func CheckCodes() error {
+	counts := make(map[string]int)
+	for _, e := range Registered() {
+		counts[e.Code]++
+	}
+
+	var duplicates []string
+	for code, n := range counts {
+		if n > 1 {
+			duplicates = append(duplicates, fmt.Sprintf("%s (%d errors)", code, n))
+		}
+	}
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	sort.Strings(duplicates)
+	return fmt.Errorf("duplicate error codes: %s", strings.Join(duplicates, ", "))
+}
+
+// registered returns the registered error with the code, or nil if there is none.
+func registered(code string) *ErrorX {
+	snapshot := registry.snapshot.Load()
+	if snapshot == nil {
+		return nil
+	}
+	return snapshot.byCode[code]
+}
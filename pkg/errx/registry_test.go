@@ -0,0 +1,32 @@
+package errx_test
+
+import (
+	"go-start-template/pkg/errx"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistered(t *testing.T) {
+	require.NoError(t, errx.CheckCodes())
+
+	errs := errx.Registered()
+	require.Contains(t, errs, errx.ErrNotFound)
+	for i := 1; i < len(errs); i++ {
+		require.LessOrEqual(t, errs[i-1].Code, errs[i].Code)
+	}
+
+	// Errors created with New and methods of registered errors are not registered
+	_ = errx.ErrNotFound.WithDetail("id", "1")
+	_ = errx.New(errx.NotFound, "Model not found", "TEST_NOT_REGISTERED")
+	require.Len(t, errx.Registered(), len(errs))
+}
+
+func TestNewSentinel(t *testing.T) {
+	count := len(errx.Registered())
+
+	sentinel := errx.NewSentinel(errx.PreconditionFailed, "Model is archived", "TEST_MODEL_ARCHIVED")
+
+	require.Len(t, errx.Registered(), count+1)
+	require.Contains(t, errx.Registered(), sentinel)
+}
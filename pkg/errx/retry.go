@@ -0,0 +1,53 @@
+package errx
+
+import "errors"
+
+// WithRetryable returns a copy of the ErrorX marked as retryable or not.
+// Retryable errors are caused by temporary conditions (deadlocks, network timeouts, ...),
+// so the operation may succeed if it is retried. The mark overrides the default
+// of the type of the error, e.g. a Timeout error marked as not retryable is not retried.
+// See IsRetryable.
+func (e *ErrorX) WithRetryable(retryable bool) *ErrorX {
+	newErr := e.clone()
+	newErr.Retryable = &retryable
+	newErr.captureStack()
+	return newErr
+}
+
+// IsRetryable reports whether the operation that failed with the error may succeed if retried.
+// Errors marked with WithRetryable are retryable according to the mark, errors that are not
+// marked are retryable if they are of Unavailable or Timeout type.
+// Joined errors are retryable if all of their items are.
+// Errors that are not ErrorX are classified by the converters used by Wrap,
+// e.g. PostgreSQL deadlocks, context deadlines, gRPC deadlines, network, MongoDB
+// and PostgreSQL statement timeouts are retryable.
+//
+// The helper is intended for consistent retry decisions of Kafka handlers, outbox relays and HTTP clients:
+//
+//	if errx.IsRetryable(err) {
+//		return err // the message is redelivered
+//	}
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var e *ErrorX
+	if !errors.As(err, &e) {
+		converted, ok := convert(err)
+		if !ok {
+			return false
+		}
+		e = converted
+	}
+
+	if e.Retryable != nil {
+		return *e.Retryable
+	}
+	return retryableType(e.Type)
+}
+
+// retryableType reports whether errors of the type are retryable unless marked otherwise.
+func retryableType(t Type) bool {
+	return t == Unavailable || t == Timeout
+}
@@ -0,0 +1,87 @@
+package errx_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-start-template/pkg/errx"
+	"go-start-template/pkg/errx/errto"
+	"net"
+	"net/http/httptest"
+	"testing"
+
+	pgconnv5 "github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"unknown error", errors.New("unexpected"), false},
+		{"not found", errx.ErrNotFound, false},
+		{"unavailable", errx.ErrUnavailable, true},
+		{"marked retryable", errx.ErrConflict.WithRetryable(true), true},
+		{"timeout", errx.ErrTimeout, true},
+		{"timeout marked not retryable", errx.ErrTimeout.WithRetryable(false), false},
+		{"unavailable marked not retryable", errx.ErrUnavailable.WithRetryable(false), false},
+		{"wrapped timeout marked not retryable", errx.Wrap(errx.ErrTimeout.WithRetryable(false)), false},
+		{"context deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), true},
+		{"context canceled", context.Canceled, false},
+		{"grpc deadline", status.Error(codes.DeadlineExceeded, "deadline exceeded"), true},
+		{"net timeout", &net.DNSError{IsTimeout: true}, true},
+		{"mongo timeout", mongo.CommandError{Code: 50, Name: "MaxTimeMSExpired"}, true},
+		{"query canceled", &pgconnv5.PgError{Code: "57014"}, true},
+		{"deadlock", &pgconnv5.PgError{Code: "40P01"}, true},
+		{"wrapped deadlock", errx.Wrap(&pgconnv5.PgError{Code: "40001"}), true},
+		{"unique violation", &pgconnv5.PgError{Code: "23505"}, false},
+		{"grpc unavailable", status.Error(codes.Unavailable, "connection refused"), true},
+		{"grpc not found", status.Error(codes.NotFound, "not found"), false},
+		{"all items retryable", errx.Join(errx.ErrUnavailable, nil, errx.ErrTimeout.WithRetryable(true)), true},
+		{"some items retryable", errx.Join(errx.ErrUnavailable, errx.ErrNotFound), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, errx.IsRetryable(tt.err))
+		})
+	}
+}
+
+func TestTimeoutsAreMarkedRetryable(t *testing.T) {
+	errs := []error{
+		fmt.Errorf("query: %w", context.DeadlineExceeded),
+		status.Error(codes.DeadlineExceeded, "deadline exceeded"),
+		&net.DNSError{IsTimeout: true},
+		mongo.CommandError{Code: 50, Name: "MaxTimeMSExpired"},
+		&pgconnv5.PgError{Code: "57014"},
+	}
+
+	for _, err := range errs {
+		var e *errx.ErrorX
+		require.True(t, errors.As(errx.Wrap(err), &e))
+		require.Equal(t, errx.Timeout, e.Type, err.Error())
+		require.True(t, errx.IsRetryable(e), err.Error())
+	}
+}
+
+func TestRetryableOverrideIsTransferred(t *testing.T) {
+	err := errx.ErrUnavailable.WithRetryable(false)
+
+	w := httptest.NewRecorder()
+	errto.HTTP(w, err)
+	require.False(t, errx.IsRetryable(errx.FromHTTPResponse(w.Result())))
+	require.False(t, errx.IsRetryable(errx.Wrap(errto.GRPC(err))))
+
+	// Without the mark the retryability is derived from the type on both sides
+	w = httptest.NewRecorder()
+	errto.HTTP(w, errx.ErrUnavailable)
+	require.True(t, errx.IsRetryable(errx.FromHTTPResponse(w.Result())))
+	require.True(t, errx.IsRetryable(errx.Wrap(errto.GRPC(errx.ErrUnavailable))))
+}
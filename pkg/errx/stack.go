@@ -0,0 +1,125 @@
+package errx
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// MaxStackDepth is the maximum number of frames captured in the stack of an ErrorX.
+const MaxStackDepth = 32
+
+// pkgPrefix is the prefix of function names of this package.
+// Frames of this package are dropped from the top of captured stacks.
+var pkgPrefix = reflect.TypeOf(ErrorX{}).PkgPath() + "."
+
+// Frame is a single frame of the stack captured in an ErrorX.
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// String returns the frame in "function (file:line)" format.
+func (f Frame) String() string {
+	return fmt.Sprintf("%s (%s:%d)", f.Function, f.File, f.Line)
+}
+
+// StackTrace returns the stack captured when the error was created with WithDetail,
+// WithInternalDetail, WithCode or first passed to Wrap. Errors created with New or NewSentinel
+// (e.g. package level errors) have no stack until one of these methods is called on them.
+//
+// The stack is intended for logs and debugging, it is never included in HTTP and gRPC responses.
+func (e *ErrorX) StackTrace() []Frame {
+	if len(e.stack) == 0 {
+		return nil
+	}
+
+	frames := make([]Frame, 0, len(e.stack))
+	callersFrames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := callersFrames.Next()
+		frames = append(frames, Frame{
+			Function: frame.Function,
+			File:     frame.File,
+			Line:     frame.Line,
+		})
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// formatStack enables the verbose %+v format of errors, see SetFormatStack.
+var formatStack atomic.Bool
+
+// SetFormatStack enables printing the code, details, cause, trace and the captured stack
+// of errors with the %+v verb. It is disabled by default, so %+v prints the message like %v.
+// It is intended to be called once at startup, e.g. in local mode.
+func SetFormatStack(enabled bool) {
+	formatStack.Store(enabled)
+}
+
+// Format implements the fmt.Formatter interface for ErrorX.
+// The %s and %v verbs print the message of the error. If enabled with SetFormatStack,
+// %+v also prints the code, details, cause, trace and the captured stack of the error:
+//
+//	log.Printf("%+v", err)
+func (e *ErrorX) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') && formatStack.Load() {
+			_, _ = fmt.Fprintf(s, "%s (code=%s", e.Message, e.Code)
+			if len(e.Details) > 0 {
+				_, _ = fmt.Fprintf(s, " details=%v", e.Details)
+			}
+			_, _ = io.WriteString(s, ")")
+			if e.cause != nil {
+				_, _ = fmt.Fprintf(s, "\ncause: %v", e.cause)
+			}
+			if e.trace != "" {
+				_, _ = fmt.Fprintf(s, "\ntrace: %s", e.trace)
+			}
+			for _, frame := range e.StackTrace() {
+				_, _ = fmt.Fprintf(s, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+			}
+			return
+		}
+		_, _ = io.WriteString(s, e.Message)
+	case 's':
+		_, _ = io.WriteString(s, e.Message)
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", e.Message)
+	}
+}
+
+// captureStack captures the stack of the caller if the error has no stack yet.
+// Frames of this package are dropped, so the stack starts at the code that created the error.
+func (e *ErrorX) captureStack() {
+	if e.stack != nil {
+		return
+	}
+
+	// Skip runtime.Callers and captureStack itself
+	pcs := make([]uintptr, MaxStackDepth+8)
+	n := runtime.Callers(2, pcs)
+	pcs = pcs[:n]
+
+	// Drop the frames of this package from the top of the stack
+	for len(pcs) > 1 {
+		frame, _ := runtime.CallersFrames(pcs[:1]).Next()
+		if !strings.HasPrefix(frame.Function, pkgPrefix) {
+			break
+		}
+		pcs = pcs[1:]
+	}
+
+	if len(pcs) > MaxStackDepth {
+		pcs = pcs[:MaxStackDepth]
+	}
+	e.stack = pcs
+}
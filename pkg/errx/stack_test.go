@@ -0,0 +1,61 @@
+package errx_test
+
+import (
+	"errors"
+	"fmt"
+	"go-start-template/pkg/errx"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func findUser() error {
+	return errx.Wrap(errors.New("connection reset"))
+}
+
+func TestWrapCapturesStack(t *testing.T) {
+	err := findUser()
+
+	var e *errx.ErrorX
+	require.True(t, errors.As(err, &e))
+
+	frames := e.StackTrace()
+	require.NotEmpty(t, frames)
+	require.LessOrEqual(t, len(frames), errx.MaxStackDepth)
+	require.True(t, strings.HasSuffix(frames[0].Function, "errx_test.findUser"), frames[0].Function)
+	require.True(t, strings.HasSuffix(frames[1].Function, "errx_test.TestWrapCapturesStack"), frames[1].Function)
+
+	// The stack of the first wrap is kept
+	wrapped := errx.Wrap(err)
+	require.True(t, errors.As(wrapped, &e))
+	require.Equal(t, frames, e.StackTrace())
+}
+
+func TestWrapDoesNotModifyOriginal(t *testing.T) {
+	_ = errx.Wrap(errx.ErrNotFound)
+
+	require.Empty(t, errx.ErrNotFound.Trace())
+	require.Empty(t, errx.ErrNotFound.StackTrace())
+
+	withDetail := errx.ErrNotFound.WithDetail("id", "1")
+	_ = withDetail.WithDetail("name", "test")
+	require.Equal(t, map[string]any{"id": "1"}, withDetail.Details)
+}
+
+func TestFormat(t *testing.T) {
+	err := errx.Wrap(errx.ErrNotFound.WithDetail("id", "1"))
+
+	require.Equal(t, "Resource not found", fmt.Sprintf("%v", err))
+	require.Equal(t, "Resource not found", fmt.Sprintf("%s", err))
+
+	// The stack is printed only if enabled
+	require.Equal(t, "Resource not found", fmt.Sprintf("%+v", err))
+
+	errx.SetFormatStack(true)
+	t.Cleanup(func() { errx.SetFormatStack(false) })
+
+	verbose := fmt.Sprintf("%+v", err)
+	require.True(t, strings.HasPrefix(verbose, "Resource not found (code=NOT_FOUND details=map[id:1])"), verbose)
+	require.Contains(t, verbose, "errx_test.TestFormat")
+}
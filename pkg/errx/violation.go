@@ -0,0 +1,85 @@
+package errx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldViolation describes a field of the request that failed validation.
+type FieldViolation struct {
+
+	// Field is the path of the field including nested fields and array indices, e.g. "items[0].name".
+	Field string `json:"field"`
+
+	// Rule is the validation rule the field violates, e.g. "required" or "min".
+	Rule string `json:"rule"`
+
+	// Param is the parameter of the rule, e.g. "3" for "min=3". It is empty for rules without parameters.
+	Param string `json:"param,omitempty"`
+
+	// Message is the human-readable description of the violation.
+	Message string `json:"message"`
+}
+
+// WithFieldViolations returns a copy of the ErrorX with the given field violations appended.
+// Field violations are serialized in HTTP responses and transferred as
+// errdetails.BadRequest field violations in gRPC status errors.
+func (e *ErrorX) WithFieldViolations(violations ...FieldViolation) *ErrorX {
+	newErr := e.clone()
+	newErr.Violations = append(newErr.Violations, violations...)
+	newErr.captureStack()
+	return newErr
+}
+
+// fieldViolation creates the violation of the validator field error.
+func fieldViolation(fe validator.FieldError) FieldViolation {
+	field := fieldPath(fe.Namespace())
+	return FieldViolation{
+		Field:   field,
+		Rule:    fe.Tag(),
+		Param:   fe.Param(),
+		Message: violationMessage(field, fe.Tag(), fe.Param()),
+	}
+}
+
+// fieldPath drops the name of the validated struct from the namespace of the field,
+// e.g. "CreateReq.items[0].name" becomes "items[0].name".
+func fieldPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
+
+// violationMessage returns the human-readable description of the common validation rules.
+func violationMessage(field, rule, param string) string {
+	switch rule {
+	case "required", "required_if", "required_unless", "required_with", "required_without":
+		return fmt.Sprintf("%s is required", field)
+	case "min", "gte":
+		return fmt.Sprintf("%s must be at least %s", field, param)
+	case "max", "lte":
+		return fmt.Sprintf("%s must be at most %s", field, param)
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", field, param)
+	case "lt":
+		return fmt.Sprintf("%s must be less than %s", field, param)
+	case "len":
+		return fmt.Sprintf("%s must have length %s", field, param)
+	case "eq":
+		return fmt.Sprintf("%s must be equal to %s", field, param)
+	case "ne":
+		return fmt.Sprintf("%s must not be equal to %s", field, param)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of [%s]", field, param)
+	case "email", "url", "uri", "uuid", "ip", "hostname", "hostname_port", "datetime":
+		return fmt.Sprintf("%s must be a valid %s", field, rule)
+	}
+
+	if param != "" {
+		return fmt.Sprintf("%s does not satisfy %s=%s", field, rule, param)
+	}
+	return fmt.Sprintf("%s does not satisfy %s", field, rule)
+}
@@ -0,0 +1,41 @@
+package errx_test
+
+import (
+	"errors"
+	"go-start-template/pkg/errx"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type order struct {
+	Title string `json:"title" validate:"min=3"`
+	Items []item `json:"items" validate:"dive"`
+}
+
+func TestWrapValidatorErrors(t *testing.T) {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		return strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	})
+
+	err := errx.Wrap(v.Struct(order{Title: "ab", Items: []item{{Name: "a"}, {}}}))
+
+	var e *errx.ErrorX
+	require.True(t, errors.As(err, &e))
+	require.True(t, errors.Is(err, errx.ErrValidation))
+	require.Equal(t, []errx.FieldViolation{
+		{Field: "title", Rule: "min", Param: "3", Message: "title must be at least 3"},
+		{Field: "items[1].name", Rule: "required", Message: "items[1].name is required"},
+	}, e.Violations)
+
+	// Violations are not flattened into details, where fields of different items would collide
+	require.Empty(t, e.Details)
+}
@@ -6,23 +6,33 @@ import (
 )
 
 // Wrap wraps an error with an ErrorX. If the error is nil, Wrap returns nil.
-// If error is not an ErrorX, it is wrapped with an ErrorX by utility functions like fromPG and fromGRPC.
-// If after wrapping the error is still not an ErrorX, it is considered that the error is not properly handled
-// and is wrapped with an ErrorX with Internal type and the error message as a detail.
-// The function also appends the caller information as a stacktrace of the function that invoked the function
+// If error is not an ErrorX, it is converted to an ErrorX by the registered and built-in converters
+// (see RegisterConverter). If no converter recognizes the error, it is considered that the error
+// is not properly handled and is wrapped with an ErrorX with Internal type and the error message as an internal detail.
+// The function also appends the caller information as a stacktrace of the function that invoked the function,
+// and captures the full stack of the caller if the error has no stack yet (see ErrorX.StackTrace).
+//
+// The converted error is kept as the cause of the ErrorX and is accessible with errors.As and errors.Unwrap.
+//
+// Wrap never modifies the given error, so it is safe to wrap package level errors.
 func Wrap(err error) error {
 	if err == nil {
 		return nil
 	}
 
-	err = fromPG(err)
-	err = fromGRPC(err)
-
 	e, ok := err.(*ErrorX)
-	if !ok {
-		e = ErrInternal.WithDetail("error", err.Error())
+	if ok {
+		e = e.clone()
+	} else {
+		converted, ok := convert(err)
+		if !ok {
+			converted = ErrInternal.WithInternalDetail("error", err.Error())
+		}
+		e = converted.clone()
+		e.cause = err
 	}
 
+	e.captureStack()
 	e.addTrace()
 	return e
 }
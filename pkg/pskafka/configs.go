@@ -2,6 +2,7 @@ package pskafka
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/segmentio/kafka-go/sasl"
@@ -24,7 +25,7 @@ const (
 type SubscriberConfig struct {
 
 	// The list of broker addresses used to connect to the kafka cluster.
-	Brokers []string `validate:"required,hostname_port"`
+	Brokers []string `validate:"required,dive,hostname_port"`
 
 	// The security protocol used to communicate with the brokers.
 	// Default is PLAINTEXT.
@@ -41,6 +42,16 @@ type SubscriberConfig struct {
 	// Required if SecurityProtocol is SASL_SCRUM
 	SaslScrumConfig *SaslScrumConfig
 
+	// The offset a consumer group starts reading from when it has no committed offsets.
+	// Use kafka.FirstOffset or kafka.LastOffset. Default is kafka.FirstOffset.
+	StartOffset int64
+
+	// The maximum time a consumer may handle a single message before it is reported as stuck,
+	// and the maximum time it may stay without fetching from the brokers before it is
+	// reported as disconnected. Idle consumers keep fetching, so they stay connected.
+	// Default is 5 minutes.
+	MaxFetchInterval time.Duration
+
 	// TODO: Implement other security protocols
 }
 
@@ -70,6 +81,76 @@ func (c *SubscriberConfig) validate() error {
 	return nil
 }
 
+// PublisherConfig is the configuration for the publisher.
+type PublisherConfig struct {
+
+	// The list of broker addresses used to connect to the kafka cluster.
+	Brokers []string `validate:"required,dive,hostname_port"`
+
+	// The security protocol used to communicate with the brokers.
+	// Default is PLAINTEXT.
+	SecurityProtocol string `validate:"required,oneof=PLAINTEXT SASL_PLAINTEXT SASL_SCRUM" default:"PLAINTEXT"`
+
+	// The configuration for SASL_PLAINTEXT security protocol.
+	// Required if SecurityProtocol is SASL_PLAINTEXT
+	SaslPlaintextConfig *SaslPlaintextConfig
+
+	// The configuration for SASL_SCRUM security protocol.
+	// Required if SecurityProtocol is SASL_SCRUM
+	SaslScrumConfig *SaslScrumConfig
+
+	// The topic that replies to requests made with Publisher.Request are published to.
+	// Required only for request/reply messaging. The topic may be shared by the instances
+	// of the application, every instance consumes all replies, see Publisher.ConsumeReplies.
+	ReplyTopic string
+
+	// The maximum time Publisher.Request waits for a reply
+	// when the context has no deadline. Default is 30 seconds.
+	RequestTimeout time.Duration
+}
+
+func (c *PublisherConfig) validate() error {
+	v := validator.New()
+	err := v.Struct(c)
+
+	failedFields := make([]string, 0)
+	if errs, ok := err.(validator.ValidationErrors); ok { //nolint: errorlint
+		for _, err := range errs {
+			failedFields = append(failedFields, err.Field())
+		}
+	}
+
+	if len(failedFields) > 0 {
+		return fmt.Errorf("%w: failed_keys: %v", ErrInvalidPublisherConfig, failedFields)
+	}
+
+	if c.SecurityProtocol == SaslPlaintext && c.SaslPlaintextConfig == nil {
+		return fmt.Errorf("SaslPlaintextConfig is required for SASL_PLAINTEXT security protocol")
+	}
+
+	if c.SecurityProtocol == SaslScrum && c.SaslScrumConfig == nil {
+		return fmt.Errorf("SaslScrumConfig is required for SASL_SCRUM security protocol")
+	}
+
+	return nil
+}
+
+// saslMechanism returns the SASL mechanism for the given security protocol.
+// It returns nil mechanism for PLAINTEXT protocol.
+func saslMechanism(protocol string, plainCfg *SaslPlaintextConfig, scrumCfg *SaslScrumConfig) (sasl.Mechanism, error) {
+	switch protocol {
+	case Plaintext:
+		// No SASL mechanism needed
+		return nil, nil //nolint: nilnil
+	case SaslPlaintext:
+		return plainCfg.mechanism()
+	case SaslScrum:
+		return scrumCfg.mechanism()
+	default:
+		return nil, fmt.Errorf("unsupported security protocol: %s", protocol)
+	}
+}
+
 // SaslPlaintextConfig is the configuration for SASL_PLAINTEXT security protocol.
 type SaslPlaintextConfig struct {
 	Username string `validate:"required"`
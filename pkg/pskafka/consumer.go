@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/segmentio/kafka-go"
 )
 
@@ -18,39 +20,44 @@ func NewSubscriber(cfg *SubscriberConfig) (*Subscriber, error) {
 		return nil, err
 	}
 
-	dialer := kafka.DefaultDialer
+	dialer, err := NewDialer(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	// Set dialer SASL mechanism based on the configuration
-	switch cfg.SecurityProtocol {
-	case Plaintext:
-		// No SASL mechanism needed
-	case SaslPlaintext:
-		mechanism, err := cfg.SaslPlaintextConfig.mechanism()
-		if err != nil {
-			return nil, err
-		}
-		dialer.SASLMechanism = mechanism
-	case SaslScrum:
-		mechanism, err := cfg.SaslScrumConfig.mechanism()
-		if err != nil {
-			return nil, err
-		}
-		dialer.SASLMechanism = mechanism
-	default:
-		return nil, fmt.Errorf("unsupported security protocol: %s", cfg.SecurityProtocol)
+	maxFetchInterval := cfg.MaxFetchInterval
+	if maxFetchInterval <= 0 {
+		maxFetchInterval = defaultMaxFetchInterval
 	}
 
 	subscriber := &Subscriber{
-		brokers:    cfg.Brokers,
-		groupID:    cfg.GroupID,
-		dialer:     dialer,
-		shutdownCh: make(chan struct{}),
-		doneCh:     make(chan struct{}),
+		brokers:          cfg.Brokers,
+		groupID:          cfg.GroupID,
+		startOffset:      cfg.StartOffset,
+		dialer:           dialer,
+		maxFetchInterval: maxFetchInterval,
+		shutdownCh:       make(chan struct{}),
+		doneCh:           make(chan struct{}),
 	}
+	subscriber.handlerCtx, subscriber.cancelHandlers = context.WithCancel(context.Background())
 
 	return subscriber, nil
 }
 
+// NewDialer returns a dialer connecting to the brokers with the security protocol of the configuration.
+// It is intended for readers that are not managed by a subscriber, e.g. readers of single partitions.
+func NewDialer(cfg *SubscriberConfig) (*kafka.Dialer, error) {
+	mechanism, err := saslMechanism(cfg.SecurityProtocol, cfg.SaslPlaintextConfig, cfg.SaslScrumConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy the default dialer, so the SASL mechanism is not shared between dialers
+	dialer := *kafka.DefaultDialer
+	dialer.SASLMechanism = mechanism
+	return &dialer, nil
+}
+
 // Subscriber is an abstraction that groups multiple consumers.
 // It provides a way to subscribe to multiple topics and consume messages.
 // It also provides a way to add global and local interceptors.
@@ -59,11 +66,18 @@ type Subscriber struct {
 	groupID string
 	dialer  *kafka.Dialer
 
+	startOffset      int64
+	maxFetchInterval time.Duration
+
 	interceptors []InterceptorFunc
 	consumers    []consumer
 
 	shutdownCh chan struct{}
 	doneCh     chan struct{}
+
+	// The context of handlers, it is canceled when the shutdown deadline is exceeded
+	handlerCtx     context.Context
+	cancelHandlers context.CancelFunc
 }
 
 // Subscribe adds a consumer to the subscriber with a topic and a handler.
@@ -71,6 +85,7 @@ func (s *Subscriber) Subscribe(topic string, handler HandleFunc) {
 	s.consumers = append(s.consumers, consumer{
 		topic:   topic,
 		handler: handler,
+		state:   newConsumerState(topic),
 	})
 }
 
@@ -80,6 +95,7 @@ func (s *Subscriber) SubscribeWithInterceptors(topic string, interceptors []Inte
 		topic:             topic,
 		localInterceptors: interceptors,
 		handler:           handler,
+		state:             newConsumerState(topic),
 	})
 }
 
@@ -107,6 +123,9 @@ func (c *Subscriber) Consume() {
 }
 
 // Shutdown closes all consumers and waits for them to finish processing messages.
+// Consumers stop fetching messages at once, while the messages being handled are processed
+// with the context of the handlers until the context of Shutdown is done,
+// then the context of the handlers is canceled.
 func (c *Subscriber) Shutdown(ctx context.Context) error {
 	close(c.shutdownCh)
 
@@ -115,42 +134,57 @@ func (c *Subscriber) Shutdown(ctx context.Context) error {
 		fmt.Println("All consumers have been shutdown")
 		return nil
 	case <-ctx.Done():
+		c.cancelHandlers()
 		fmt.Println("Timeout waiting for consumers to shutdown")
 		return ctx.Err()
 	}
 }
 
+// subscribeInstance adds a consumer that reads the topic with a consumer group of its own,
+// so every instance of the application sharing the group ID of the subscriber receives
+// all messages of the topic. The group starts reading from the last offset.
+func (s *Subscriber) subscribeInstance(topic string, handler HandleFunc) {
+	s.consumers = append(s.consumers, consumer{
+		topic:       topic,
+		handler:     handler,
+		groupID:     fmt.Sprintf("%s-%s", s.groupID, uuid.NewString()),
+		startOffset: kafka.LastOffset,
+		state:       newConsumerState(topic),
+	})
+}
+
 // consumer is an abstraction that groups a topic, a handler, and local interceptors.
+// It also holds the state of the consumer used for health reporting.
+// The group ID and start offset of the subscriber are used unless the consumer sets its own.
 type consumer struct {
 	topic             string
 	handler           HandleFunc
 	localInterceptors []InterceptorFunc
+	groupID           string
+	startOffset       int64
+	state             *consumerState
 }
 
 // chainInterceptors chains global and local interceptors to the consumer's handler.
 // It returns the final handler that will be used to consume messages.
 func (s *Subscriber) chainInterceptors(subscriber consumer) HandleFunc {
-	chain := subscriber.handler
-	for i := len(subscriber.localInterceptors) - 1; i >= 0; i-- {
-		chain = func(next HandleFunc, i InterceptorFunc) HandleFunc {
-			return func(ctx context.Context, msg kafka.Message) error {
-				return i(ctx, msg, func(ctx context.Context, msg kafka.Message) error {
-					return next(ctx, msg)
-				})
-			}
-		}(chain, subscriber.localInterceptors[i])
-	}
+	chain := chainHandler(subscriber.handler, subscriber.localInterceptors)
+	return chainHandler(chain, s.interceptors)
+}
 
-	for i := len(s.interceptors) - 1; i >= 0; i-- {
+// chainHandler wraps the handler with the interceptors.
+// The first interceptor is the outermost one, so it is called first.
+func chainHandler(handler HandleFunc, interceptors []InterceptorFunc) HandleFunc {
+	chain := handler
+	for i := len(interceptors) - 1; i >= 0; i-- {
 		chain = func(next HandleFunc, i InterceptorFunc) HandleFunc {
 			return func(ctx context.Context, msg kafka.Message) error {
 				return i(ctx, msg, func(ctx context.Context, msg kafka.Message) error {
 					return next(ctx, msg)
 				})
 			}
-		}(chain, s.interceptors[i])
+		}(chain, interceptors[i])
 	}
-
 	return chain
 }
 
@@ -165,17 +199,20 @@ func (s *Subscriber) chainInterceptors(subscriber consumer) HandleFunc {
 //
 // For now it reads messages one by one and processes them synchronously
 func (s *Subscriber) consume(subscriber consumer) {
+	groupID, startOffset := s.groupID, s.startOffset
+	if subscriber.groupID != "" {
+		groupID, startOffset = subscriber.groupID, subscriber.startOffset
+	}
+
 	r := kafka.NewReader(kafka.ReaderConfig{
-		Brokers: s.brokers,
-		Dialer:  s.dialer,
-		GroupID: s.groupID,
-		Topic:   subscriber.topic,
+		Brokers:     s.brokers,
+		Dialer:      s.dialer,
+		GroupID:     groupID,
+		Topic:       subscriber.topic,
+		StartOffset: startOffset,
 	})
 
-	handler := s.chainInterceptors(subscriber)
-
-	go func() {
-		<-s.shutdownCh
+	defer func() {
 		err := r.Close()
 		if err != nil {
 			fmt.Println("Error closing reader:", err)
@@ -184,15 +221,59 @@ func (s *Subscriber) consume(subscriber consumer) {
 		}
 	}()
 
+	subscriber.state.started(r)
+	s.handleMessages(subscriber, r)
+}
+
+// messageFetcher fetches messages of a consumer, it is implemented by kafka.Reader.
+type messageFetcher interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+}
+
+// handleMessages fetches messages and calls the consumer's handler until the subscriber is shut down.
+// Fetching is interrupted on shutdown, but the message being handled is not: the handler
+// is called with the context of handlers, which is canceled only when the shutdown deadline
+// is exceeded. Interceptors waiting before the handler (e.g. RateLimit) stop on shutdown, see Stopping.
+func (s *Subscriber) handleMessages(subscriber consumer, r messageFetcher) {
+	handler := s.chainInterceptors(subscriber)
+
+	fetchCtx, cancelFetch := context.WithCancel(context.Background())
+	defer cancelFetch()
+
+	go func() {
+		select {
+		case <-s.shutdownCh:
+			cancelFetch()
+		case <-fetchCtx.Done():
+		}
+	}()
+
+	ctx := context.WithValue(s.handlerCtx, stoppingKey{}, (<-chan struct{})(s.shutdownCh))
+
 	for {
-		ctx := context.Background()
-		m, err := r.FetchMessage(ctx)
+		m, err := r.FetchMessage(fetchCtx)
 		if err != nil {
+			subscriber.state.stopped(err)
 			return
 		}
 
+		subscriber.state.fetched(m)
 		if err := handler(ctx, m); err != nil {
+			subscriber.state.stopped(err)
 			return
 		}
+		subscriber.state.handled()
 	}
 }
+
+type stoppingKey struct{}
+
+// Stopping returns a channel that is closed when the subscriber handling the message
+// of the context is shut down. Unlike the context itself, which stays alive until
+// the shutdown deadline so the handler can finish, the channel is closed at once,
+// so interceptors can stop waiting before the handler is called.
+// It returns nil (a channel that is never closed) for other contexts.
+func Stopping(ctx context.Context) <-chan struct{} {
+	ch, _ := ctx.Value(stoppingKey{}).(<-chan struct{})
+	return ch
+}
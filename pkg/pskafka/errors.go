@@ -1,7 +1,27 @@
 package pskafka
 
-import "errors"
+import (
+	"errors"
+	"go-start-template/pkg/errx"
+)
 
 var (
 	ErrInvalidSubscriberConfig = errors.New("invalid subscriber config")
+	ErrInvalidPublisherConfig  = errors.New("invalid publisher config")
+
+	ErrReplyTopicNotSet    = errors.New("reply topic is not set in publisher config")
+	ErrRepliesNotConsumed  = errors.New("replies are not consumed, call Publisher.ConsumeReplies first")
+	ErrMissingReplyHeaders = errors.New("message has no reply-to or correlation-id header")
+
+	ErrSubscriberStopping = errors.New("subscriber is shutting down")
+)
+
+const (
+	// CodeRequestTimeout is the error code returned when a reply is not received in time.
+	CodeRequestTimeout = "KAFKA_REQUEST_TIMEOUT"
+)
+
+var (
+	// ErrRequestTimeout is returned by Publisher.Request when a reply is not received in time.
+	ErrRequestTimeout = errx.NewSentinel(errx.Timeout, "Kafka request timed out", CodeRequestTimeout)
 )
@@ -0,0 +1,36 @@
+package pskafka
+
+import "github.com/segmentio/kafka-go"
+
+const (
+	// HeaderCorrelationID is the header used to match replies with their requests.
+	HeaderCorrelationID = "correlation-id"
+
+	// HeaderReplyTo is the header that holds the topic the reply should be published to.
+	HeaderReplyTo = "reply-to"
+
+	// HeaderRequestID is the header that holds the ID of the request the message is published for.
+	HeaderRequestID = "request-id"
+)
+
+// HeaderValue returns the value of the first header with the given key.
+// The second return value reports whether the header was found.
+func HeaderValue(msg kafka.Message, key string) (string, bool) {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value), true
+		}
+	}
+	return "", false
+}
+
+// setHeader sets the header with the given key, replacing existing values.
+func setHeader(msg *kafka.Message, key, value string) {
+	headers := make([]kafka.Header, 0, len(msg.Headers)+1)
+	for _, h := range msg.Headers {
+		if h.Key != key {
+			headers = append(headers, h)
+		}
+	}
+	msg.Headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+}
@@ -0,0 +1,180 @@
+package pskafka
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const defaultMaxFetchInterval = 5 * time.Minute
+
+// SubscriberHealth describes the state of all consumers of the subscriber.
+type SubscriberHealth struct {
+	Healthy   bool             `json:"healthy"`
+	Consumers []ConsumerHealth `json:"consumers"`
+}
+
+// ConsumerHealth describes the state of a single consumer.
+type ConsumerHealth struct {
+	Topic string `json:"topic"`
+
+	// Running reports whether the consumer is reading messages.
+	// It is false before Consume is called and after the consumer has stopped.
+	Running bool `json:"running"`
+
+	// Connected reports whether the consumer has fetched from the brokers recently,
+	// with or without messages. Idle consumers keep fetching, so a consumer that has not
+	// fetched for the max fetch interval has lost the connection to the brokers.
+	Connected bool `json:"connected"`
+
+	// FetchedPartitions are the partitions the consumer has fetched messages from
+	// since the last rebalance of the consumer group. The reader does not expose
+	// the assigned partitions, so assigned partitions without new messages are not listed.
+	FetchedPartitions []int `json:"fetched_partitions"`
+
+	// LastActivityAt is the time the consumer last fetched from the brokers.
+	LastActivityAt time.Time `json:"last_activity_at"`
+
+	// LastFetchAt is the time of the last successfully fetched message.
+	LastFetchAt time.Time `json:"last_fetch_at"`
+
+	// Lag is the number of messages left in the partition of the last fetched message.
+	Lag int64 `json:"lag"`
+
+	// Stuck reports whether the consumer is handling a message for too long.
+	Stuck bool `json:"stuck"`
+
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Health returns the state of the subscriber consumers.
+// The subscriber is healthy when all of its consumers are running, connected and not stuck.
+func (s *Subscriber) Health() SubscriberHealth {
+	health := SubscriberHealth{
+		Healthy:   true,
+		Consumers: make([]ConsumerHealth, 0, len(s.consumers)),
+	}
+
+	for _, c := range s.consumers {
+		consumerHealth := c.state.health(s.maxFetchInterval)
+		health.Healthy = health.Healthy && consumerHealth.Healthy
+		health.Consumers = append(health.Consumers, consumerHealth)
+	}
+
+	return health
+}
+
+// consumerState tracks the state of a consumer for health reporting.
+type consumerState struct {
+	mu sync.Mutex
+
+	topic      string
+	reader     *kafka.Reader
+	running    bool
+	partitions map[int]struct{}
+	err        error
+
+	lastActivityAt time.Time
+	lastFetchAt    time.Time
+	lag            int64
+
+	handling        bool
+	handleStartedAt time.Time
+}
+
+func newConsumerState(topic string) *consumerState {
+	return &consumerState{
+		topic:      topic,
+		partitions: make(map[int]struct{}),
+	}
+}
+
+// started marks the consumer as running with the given reader.
+func (st *consumerState) started(r *kafka.Reader) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.reader = r
+	st.running = true
+	st.err = nil
+
+	// Connecting to the brokers is given the max fetch interval
+	st.lastActivityAt = time.Now()
+}
+
+// stopped marks the consumer as not running with the error that stopped it.
+func (st *consumerState) stopped(err error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.running = false
+	st.handling = false
+	st.err = err
+}
+
+// fetched records the successfully fetched message and marks the consumer as handling it.
+func (st *consumerState) fetched(m kafka.Message) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	now := time.Now()
+	st.lastActivityAt = now
+	st.lastFetchAt = now
+	st.lag = m.HighWaterMark - m.Offset - 1
+	st.partitions[m.Partition] = struct{}{}
+	st.handling = true
+	st.handleStartedAt = now
+}
+
+// handled marks the consumer as waiting for the next message.
+func (st *consumerState) handled() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.handling = false
+}
+
+func (st *consumerState) health(maxFetchInterval time.Duration) ConsumerHealth {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	now := time.Now()
+
+	// Reader stats are reset on every call, so they count the fetches since the previous call.
+	// Partitions fetched before a rebalance may no longer be assigned to the consumer.
+	if st.reader != nil {
+		stats := st.reader.Stats()
+		if stats.Fetches > 0 {
+			st.lastActivityAt = now
+		}
+		if stats.Rebalances > 0 {
+			st.partitions = make(map[int]struct{})
+		}
+	}
+
+	health := ConsumerHealth{
+		Topic:             st.topic,
+		Running:           st.running,
+		Connected:         st.running && now.Sub(st.lastActivityAt) <= maxFetchInterval,
+		FetchedPartitions: make([]int, 0, len(st.partitions)),
+		LastActivityAt:    st.lastActivityAt,
+		LastFetchAt:       st.lastFetchAt,
+		Lag:               st.lag,
+		Stuck:             st.handling && now.Sub(st.handleStartedAt) > maxFetchInterval,
+	}
+	health.Healthy = health.Running && health.Connected && !health.Stuck
+
+	for p := range st.partitions {
+		health.FetchedPartitions = append(health.FetchedPartitions, p)
+	}
+	sort.Ints(health.FetchedPartitions)
+
+	if st.err != nil {
+		health.Error = st.err.Error()
+	}
+
+	return health
+}
@@ -0,0 +1,53 @@
+package pskafka
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsumerStateHealth(t *testing.T) {
+	st := newConsumerState("orders")
+
+	health := st.health(time.Minute)
+	require.False(t, health.Running)
+	require.False(t, health.Healthy)
+
+	st.started(nil)
+	st.fetched(kafka.Message{Partition: 2, Offset: 10, HighWaterMark: 11})
+	st.handled()
+
+	health = st.health(time.Minute)
+	require.True(t, health.Running)
+	require.True(t, health.Connected)
+	require.Equal(t, []int{2}, health.FetchedPartitions)
+	require.Zero(t, health.Lag)
+	require.True(t, health.Healthy)
+
+	// Idle consumer that keeps fetching from the brokers is healthy
+	st.lastFetchAt = time.Now().Add(-time.Hour)
+	require.True(t, st.health(time.Minute).Healthy)
+
+	// Consumer that has not fetched from the brokers for too long is disconnected, even without lag
+	st.lastActivityAt = time.Now().Add(-time.Hour)
+	health = st.health(time.Minute)
+	require.False(t, health.Connected)
+	require.False(t, health.Stuck)
+	require.False(t, health.Healthy)
+
+	// Consumer handling a message for too long is stuck
+	st.fetched(kafka.Message{Partition: 2, Offset: 11, HighWaterMark: 12})
+	require.True(t, st.health(time.Minute).Healthy)
+	st.handleStartedAt = time.Now().Add(-time.Hour)
+	health = st.health(time.Minute)
+	require.True(t, health.Stuck)
+	require.False(t, health.Healthy)
+
+	st.stopped(errors.New("handler failed"))
+	health = st.health(time.Minute)
+	require.False(t, health.Running)
+	require.Equal(t, "handler failed", health.Error)
+}
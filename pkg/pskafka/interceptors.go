@@ -1 +1,116 @@
 package pskafka
+
+import (
+	"context"
+	"fmt"
+	"go-start-template/pkg/requestid"
+	"log/slog"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"golang.org/x/time/rate"
+)
+
+// RateLimitConfig is the configuration for the rate limit interceptor.
+type RateLimitConfig struct {
+
+	// The number of messages per second allowed to reach the handler.
+	// Zero or negative value disables the limit.
+	PerSecond float64
+
+	// The maximum number of messages allowed to reach the handler at once.
+	// Default is 1.
+	Burst int
+
+	// The logger used to report throttled messages at debug level. Optional.
+	Logger *slog.Logger
+
+	// OnThrottle is called with the time a throttled message waited for the limiter.
+	// It is not called for messages that did not wait.
+	// It is intended for custom reporting of the throttling time. Optional.
+	OnThrottle func(msg kafka.Message, wait time.Duration)
+
+	// Metrics records the time throttled messages waited by topic in Prometheus. Optional.
+	Metrics *ThrottleMetrics
+}
+
+// RateLimit returns a token bucket interceptor that limits the rate of messages
+// passed to the next handler. The interceptor blocks until the message is allowed,
+// the context is done, in which case the context error is returned, or the subscriber
+// is shut down, in which case ErrSubscriberStopping is returned and the message is not handled.
+//
+// Every call creates a separate limiter, so the interceptor is intended
+// to be used as a local interceptor of a single subscription:
+//
+//	subscriber.SubscribeWithInterceptors("notifications", []pskafka.InterceptorFunc{
+//		pskafka.RateLimit(pskafka.RateLimitConfig{PerSecond: 10, Burst: 5}),
+//	}, handler)
+func RateLimit(cfg RateLimitConfig) InterceptorFunc {
+	limit := rate.Limit(cfg.PerSecond)
+	if cfg.PerSecond <= 0 {
+		limit = rate.Inf
+	}
+
+	burst := cfg.Burst
+	if burst < 1 {
+		burst = 1
+	}
+
+	limiter := rate.NewLimiter(limit, burst)
+
+	return func(ctx context.Context, msg kafka.Message, next HandleFunc) error {
+		reservation := limiter.Reserve()
+		if !reservation.OK() {
+			return fmt.Errorf("rate limiter can not reserve a token for burst %d", burst)
+		}
+
+		wait := reservation.Delay()
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				reservation.Cancel()
+				return ctx.Err()
+			case <-Stopping(ctx):
+				timer.Stop()
+				reservation.Cancel()
+				return ErrSubscriberStopping
+			}
+
+			if cfg.Logger != nil {
+				cfg.Logger.Debug("Kafka message throttled",
+					"topic", msg.Topic,
+					"partition", msg.Partition,
+					"offset", msg.Offset,
+					"wait", wait.String(),
+				)
+			}
+		}
+
+		if wait > 0 && cfg.Metrics != nil {
+			cfg.Metrics.observeThrottle(msg.Topic, wait)
+		}
+		if wait > 0 && cfg.OnThrottle != nil {
+			cfg.OnThrottle(msg, wait)
+		}
+
+		return next(ctx, msg)
+	}
+}
+
+// RequestID returns an interceptor that puts the request ID of the request-id header into the context
+// of the handler, or a new request ID if the message has none. The request ID is propagated to the
+// messages published with the context, so the messages handled for a request can be correlated.
+//
+//	subscriber.Use(pskafka.RequestID())
+func RequestID() InterceptorFunc {
+	return func(ctx context.Context, msg kafka.Message, next HandleFunc) error {
+		id, ok := HeaderValue(msg, HeaderRequestID)
+		if !ok || !requestid.Valid(id) {
+			id = requestid.New()
+		}
+		return next(requestid.NewContext(ctx, id), msg)
+	}
+}
@@ -0,0 +1,92 @@
+package pskafka_test
+
+import (
+	"context"
+	"errors"
+	"go-start-template/pkg/pskafka"
+	"go-start-template/pkg/requestid"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	var waits []time.Duration
+	interceptor := pskafka.RateLimit(pskafka.RateLimitConfig{
+		PerSecond: 20,
+		Burst:     1,
+		OnThrottle: func(msg kafka.Message, wait time.Duration) {
+			waits = append(waits, wait)
+		},
+	})
+
+	handled := 0
+	next := func(ctx context.Context, msg kafka.Message) error {
+		handled++
+		return nil
+	}
+
+	require.NoError(t, interceptor(context.Background(), kafka.Message{}, next))
+	require.NoError(t, interceptor(context.Background(), kafka.Message{}, next))
+	require.Equal(t, 2, handled)
+	require.Len(t, waits, 1, "only the throttled message is reported")
+	require.Greater(t, waits[0], time.Duration(0))
+
+	// Throttled message is not handled when the context is done while waiting
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := interceptor(ctx, kafka.Message{}, next)
+	require.True(t, errors.Is(err, context.Canceled))
+	require.Equal(t, 2, handled)
+}
+
+func TestRateLimitMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	metrics, err := pskafka.NewThrottleMetrics(reg)
+	require.NoError(t, err)
+
+	// Registering again reuses the histogram
+	_, err = pskafka.NewThrottleMetrics(reg)
+	require.NoError(t, err)
+
+	interceptor := pskafka.RateLimit(pskafka.RateLimitConfig{PerSecond: 20, Metrics: metrics})
+	next := func(ctx context.Context, msg kafka.Message) error { return nil }
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, interceptor(context.Background(), kafka.Message{Topic: "notifications"}, next))
+	}
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	require.Equal(t, "pskafka_throttle_wait_seconds", families[0].GetName())
+
+	metric := families[0].GetMetric()
+	require.Len(t, metric, 1)
+	require.Equal(t, "notifications", metric[0].GetLabel()[0].GetValue())
+	require.Equal(t, uint64(2), metric[0].GetHistogram().GetSampleCount(), "only throttled messages are observed")
+	require.Greater(t, metric[0].GetHistogram().GetSampleSum(), 0.0)
+}
+
+func TestRequestID(t *testing.T) {
+	interceptor := pskafka.RequestID()
+
+	var id string
+	next := func(ctx context.Context, msg kafka.Message) error {
+		id = requestid.FromContext(ctx)
+		return nil
+	}
+
+	msg := kafka.Message{Headers: []kafka.Header{{Key: pskafka.HeaderRequestID, Value: []byte("req-1")}}}
+	require.NoError(t, interceptor(context.Background(), msg, next))
+	require.Equal(t, "req-1", id)
+
+	// A new request ID is generated for messages without one
+	require.NoError(t, interceptor(context.Background(), kafka.Message{}, next))
+	require.True(t, requestid.Valid(id))
+	require.NotEqual(t, "req-1", id)
+}
@@ -0,0 +1,49 @@
+package pskafka
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ThrottleMetrics exports the time messages waited for the RateLimit interceptor to Prometheus.
+// It is passed to RateLimit with the Metrics field of the config:
+//
+//	metrics, err := pskafka.NewThrottleMetrics(prometheus.DefaultRegisterer)
+//	...
+//	pskafka.RateLimit(pskafka.RateLimitConfig{PerSecond: 10, Metrics: metrics})
+type ThrottleMetrics struct {
+	wait *prometheus.HistogramVec
+}
+
+// NewThrottleMetrics creates the metrics and registers them in the registerer.
+// If the metrics are already registered (e.g. by another subscriber of the application),
+// the registered histogram is reused.
+func NewThrottleMetrics(reg prometheus.Registerer) (*ThrottleMetrics, error) {
+	histogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "pskafka_throttle_wait_seconds",
+		Help:    "The time throttled Kafka messages waited for the rate limit by topic.",
+		Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+	}, []string{"topic"})
+
+	err := reg.Register(histogram)
+	if err != nil {
+		var alreadyRegistered prometheus.AlreadyRegisteredError
+		if !errors.As(err, &alreadyRegistered) {
+			return nil, err
+		}
+		existing, ok := alreadyRegistered.ExistingCollector.(*prometheus.HistogramVec)
+		if !ok {
+			return nil, err
+		}
+		histogram = existing
+	}
+
+	return &ThrottleMetrics{wait: histogram}, nil
+}
+
+// observeThrottle records the wait of a throttled message of the topic.
+func (m *ThrottleMetrics) observeThrottle(topic string, wait time.Duration) {
+	m.wait.WithLabelValues(topic).Observe(wait.Seconds())
+}
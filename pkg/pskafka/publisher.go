@@ -0,0 +1,211 @@
+package pskafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-start-template/pkg/errx"
+	"go-start-template/pkg/requestid"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+)
+
+const defaultRequestTimeout = 30 * time.Second
+
+// NewPublisher validates the configuration and returns a new publisher.
+func NewPublisher(cfg *PublisherConfig) (*Publisher, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("%w: publisher config is nil", ErrInvalidPublisherConfig)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	mechanism, err := saslMechanism(cfg.SecurityProtocol, cfg.SaslPlaintextConfig, cfg.SaslScrumConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	requestTimeout := cfg.RequestTimeout
+	if requestTimeout <= 0 {
+		requestTimeout = defaultRequestTimeout
+	}
+
+	publisher := &Publisher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(cfg.Brokers...),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			Transport: &kafka.Transport{
+				SASL: mechanism,
+			},
+		},
+		replyTopic:     cfg.ReplyTopic,
+		requestTimeout: requestTimeout,
+		pending:        make(map[string]chan kafka.Message),
+	}
+
+	return publisher, nil
+}
+
+// Publisher is an abstraction over kafka writer that publishes messages to topics.
+// It also provides request/reply messaging on top of the publish/subscribe model.
+type Publisher struct {
+	writer *kafka.Writer
+
+	replyTopic     string
+	requestTimeout time.Duration
+
+	mu        sync.Mutex
+	pending   map[string]chan kafka.Message
+	consuming bool
+}
+
+// Publish writes messages to the given topic.
+// Messages with the same key are written to the same partition.
+// The request ID of the context is set as the request-id header of messages that have none.
+// The given messages are not modified.
+func (p *Publisher) Publish(ctx context.Context, topic string, msgs ...kafka.Message) error {
+	msgs = append([]kafka.Message(nil), msgs...)
+
+	requestID := requestid.FromContext(ctx)
+	for i := range msgs {
+		msgs[i].Topic = topic
+		if _, ok := HeaderValue(msgs[i], HeaderRequestID); !ok && requestID != "" {
+			setHeader(&msgs[i], HeaderRequestID, requestID)
+		}
+	}
+	return p.writer.WriteMessages(ctx, msgs...)
+}
+
+// ConsumeReplies subscribes the publisher to its reply topic with the given subscriber.
+// It must be called before the subscriber starts consuming, and before Request is used.
+//
+// Replies are consumed with a consumer group of the instance (the group ID of the subscriber
+// with a random suffix), so every instance receives all replies and picks the ones
+// of its pending requests, even if the instances share the reply topic.
+func (p *Publisher) ConsumeReplies(s *Subscriber) error {
+	if p.replyTopic == "" {
+		return ErrReplyTopicNotSet
+	}
+
+	s.subscribeInstance(p.replyTopic, p.handleReply)
+
+	p.mu.Lock()
+	p.consuming = true
+	p.mu.Unlock()
+
+	return nil
+}
+
+// Request publishes the message to the given topic and waits for the matching reply.
+// It sets the correlation-id and reply-to headers of the message, so the receiving side
+// can answer it with Publisher.Reply.
+//
+// If the context has no deadline, the RequestTimeout of the publisher config is used.
+// When the reply is not received in time, ErrRequestTimeout is returned.
+func (p *Publisher) Request(ctx context.Context, topic string, msg kafka.Message) (kafka.Message, error) {
+	p.mu.Lock()
+	consuming := p.consuming
+	p.mu.Unlock()
+
+	if !consuming {
+		return kafka.Message{}, ErrRepliesNotConsumed
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.requestTimeout)
+		defer cancel()
+	}
+
+	correlationID := uuid.NewString()
+	setHeader(&msg, HeaderCorrelationID, correlationID)
+	setHeader(&msg, HeaderReplyTo, p.replyTopic)
+
+	replyCh := p.addPending(correlationID)
+	defer p.removePending(correlationID)
+
+	if err := p.Publish(ctx, topic, msg); err != nil {
+		return kafka.Message{}, requestErr(ctx, topic, err)
+	}
+
+	select {
+	case reply := <-replyCh:
+		return reply, nil
+	case <-ctx.Done():
+		return kafka.Message{}, requestErr(ctx, topic, ctx.Err())
+	}
+}
+
+// Reply publishes the reply to the topic taken from the reply-to header of the request.
+// The correlation-id header of the request is copied to the reply,
+// so the requesting side can match them.
+func (p *Publisher) Reply(ctx context.Context, request kafka.Message, reply kafka.Message) error {
+	replyTo, ok := HeaderValue(request, HeaderReplyTo)
+	if !ok {
+		return ErrMissingReplyHeaders
+	}
+
+	correlationID, ok := HeaderValue(request, HeaderCorrelationID)
+	if !ok {
+		return ErrMissingReplyHeaders
+	}
+
+	setHeader(&reply, HeaderCorrelationID, correlationID)
+	return p.Publish(ctx, replyTo, reply)
+}
+
+// Close flushes pending writes and closes the publisher.
+func (p *Publisher) Close() error {
+	return p.writer.Close()
+}
+
+// handleReply delivers the reply to the pending request with the same correlation ID.
+// Replies without a pending request (e.g. the request has already timed out) are skipped.
+func (p *Publisher) handleReply(_ context.Context, msg kafka.Message) error {
+	correlationID, ok := HeaderValue(msg, HeaderCorrelationID)
+	if !ok {
+		return nil
+	}
+
+	p.mu.Lock()
+	replyCh, ok := p.pending[correlationID]
+	delete(p.pending, correlationID)
+	p.mu.Unlock()
+
+	if ok {
+		replyCh <- msg
+	}
+
+	return nil
+}
+
+func (p *Publisher) addPending(correlationID string) chan kafka.Message {
+	// Buffered, so handleReply never blocks on a request that stopped waiting
+	replyCh := make(chan kafka.Message, 1)
+
+	p.mu.Lock()
+	p.pending[correlationID] = replyCh
+	p.mu.Unlock()
+
+	return replyCh
+}
+
+func (p *Publisher) removePending(correlationID string) {
+	p.mu.Lock()
+	delete(p.pending, correlationID)
+	p.mu.Unlock()
+}
+
+// requestErr maps the error of a request to an ErrorX.
+func requestErr(ctx context.Context, topic string, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return ErrRequestTimeout.WithDetail("topic", topic)
+	}
+	return errx.Wrap(err)
+}
@@ -0,0 +1,107 @@
+package pskafka
+
+import (
+	"context"
+	"errors"
+	"go-start-template/pkg/requestid"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPublisher(t *testing.T) *Publisher {
+	t.Helper()
+
+	p, err := NewPublisher(&PublisherConfig{
+		Brokers:          []string{"localhost:9092"},
+		SecurityProtocol: Plaintext,
+		ReplyTopic:       "replies",
+	})
+	require.NoError(t, err)
+
+	return p
+}
+
+func TestPublisherHandleReply(t *testing.T) {
+	p := newTestPublisher(t)
+	replyCh := p.addPending("42")
+
+	// Replies for unknown requests are skipped
+	err := p.handleReply(context.Background(), kafka.Message{
+		Headers: []kafka.Header{{Key: HeaderCorrelationID, Value: []byte("unknown")}},
+	})
+	require.NoError(t, err)
+	require.Empty(t, replyCh)
+
+	err = p.handleReply(context.Background(), kafka.Message{
+		Value:   []byte("pong"),
+		Headers: []kafka.Header{{Key: HeaderCorrelationID, Value: []byte("42")}},
+	})
+	require.NoError(t, err)
+
+	reply := <-replyCh
+	require.Equal(t, "pong", string(reply.Value))
+	require.NotContains(t, p.pending, "42")
+}
+
+func TestPublisherRequestWithoutReplies(t *testing.T) {
+	p := newTestPublisher(t)
+
+	_, err := p.Request(context.Background(), "requests", kafka.Message{})
+	require.True(t, errors.Is(err, ErrRepliesNotConsumed))
+}
+
+func TestPublisherReplyWithoutHeaders(t *testing.T) {
+	p := newTestPublisher(t)
+
+	err := p.Reply(context.Background(), kafka.Message{}, kafka.Message{})
+	require.True(t, errors.Is(err, ErrMissingReplyHeaders))
+}
+
+func TestSetHeaderReplacesValue(t *testing.T) {
+	msg := kafka.Message{Headers: []kafka.Header{{Key: HeaderReplyTo, Value: []byte("old")}}}
+
+	setHeader(&msg, HeaderReplyTo, "new")
+
+	value, ok := HeaderValue(msg, HeaderReplyTo)
+	require.True(t, ok)
+	require.Equal(t, "new", value)
+	require.Len(t, msg.Headers, 1)
+}
+
+func TestPublishDoesNotModifyMessages(t *testing.T) {
+	p := newTestPublisher(t)
+
+	ctx, cancel := context.WithCancel(requestid.NewContext(context.Background(), "req-1"))
+	cancel()
+
+	msgs := []kafka.Message{{Value: []byte("ping")}}
+	_ = p.Publish(ctx, "requests", msgs...)
+
+	require.Empty(t, msgs[0].Topic)
+	require.Empty(t, msgs[0].Headers)
+}
+
+func TestConsumeRepliesWithInstanceGroup(t *testing.T) {
+	p := newTestPublisher(t)
+
+	newSubscriber := func() *Subscriber {
+		s, err := NewSubscriber(&SubscriberConfig{
+			Brokers:          []string{"localhost:9092"},
+			SecurityProtocol: Plaintext,
+			GroupID:          "app",
+		})
+		require.NoError(t, err)
+		require.NoError(t, p.ConsumeReplies(s))
+		require.Len(t, s.consumers, 1)
+		return s
+	}
+
+	// Instances sharing the group ID of the subscriber consume replies in different groups
+	first, second := newSubscriber().consumers[0], newSubscriber().consumers[0]
+	require.Equal(t, "replies", first.topic)
+	require.Contains(t, first.groupID, "app-")
+	require.NotEqual(t, first.groupID, second.groupID)
+	require.Equal(t, kafka.LastOffset, first.startOffset)
+}
@@ -0,0 +1,106 @@
+package pskafka
+
+import (
+	"context"
+	"strings"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// HeaderEventType is the conventional header that holds the type of the event.
+const HeaderEventType = "event-type"
+
+// NewRouter returns a router that dispatches messages by the value of the given header.
+// If header is empty, messages are dispatched only by key prefixes.
+func NewRouter(header string) *Router {
+	return &Router{
+		header: header,
+		routes: make(map[string]HandleFunc),
+	}
+}
+
+// Router dispatches messages of a single subscription to different handlers.
+// It is useful when multiple event types share the same topic.
+//
+// A message is dispatched to the handler registered for its header value,
+// then to the handler with the longest matching key prefix, and then to the default handler.
+// Messages that match no route and have no default handler are skipped.
+//
+// Router.Route is a HandleFunc, so the router is subscribed like any other handler
+// and the global and local interceptors of the subscription are applied before routing:
+//
+//	router := pskafka.NewRouter(pskafka.HeaderEventType)
+//	router.Handle("order.created", handleOrderCreated)
+//	router.Handle("order.canceled", handleOrderCanceled, auditInterceptor)
+//	router.HandleDefault(handleUnknownEvent)
+//
+//	subscriber.SubscribeWithInterceptors("orders", interceptors, router.Route)
+type Router struct {
+	header   string
+	routes   map[string]HandleFunc
+	prefixes []prefixRoute
+	fallback HandleFunc
+}
+
+// prefixRoute groups a key prefix and its handler.
+type prefixRoute struct {
+	prefix  string
+	handler HandleFunc
+}
+
+// Handle registers the handler for messages with the given header value.
+// Interceptors are applied only to this route, after the interceptors of the subscription.
+func (r *Router) Handle(value string, handler HandleFunc, interceptors ...InterceptorFunc) {
+	r.routes[value] = chainHandler(handler, interceptors)
+}
+
+// HandleKeyPrefix registers the handler for messages whose key starts with the given prefix.
+// Interceptors are applied only to this route, after the interceptors of the subscription.
+func (r *Router) HandleKeyPrefix(prefix string, handler HandleFunc, interceptors ...InterceptorFunc) {
+	r.prefixes = append(r.prefixes, prefixRoute{
+		prefix:  prefix,
+		handler: chainHandler(handler, interceptors),
+	})
+}
+
+// HandleDefault registers the handler for messages that match no other route.
+func (r *Router) HandleDefault(handler HandleFunc, interceptors ...InterceptorFunc) {
+	r.fallback = chainHandler(handler, interceptors)
+}
+
+// Route dispatches the message to the matching handler.
+func (r *Router) Route(ctx context.Context, msg kafka.Message) error {
+	handler := r.match(msg)
+	if handler == nil {
+		return nil
+	}
+	return handler(ctx, msg)
+}
+
+// match returns the handler for the message or nil if no route matches.
+func (r *Router) match(msg kafka.Message) HandleFunc {
+	if r.header != "" {
+		if value, ok := HeaderValue(msg, r.header); ok {
+			if handler, ok := r.routes[value]; ok {
+				return handler
+			}
+		}
+	}
+
+	var (
+		handler HandleFunc
+		longest = -1
+	)
+	key := string(msg.Key)
+	for _, route := range r.prefixes {
+		if len(route.prefix) > longest && strings.HasPrefix(key, route.prefix) {
+			handler = route.handler
+			longest = len(route.prefix)
+		}
+	}
+	if handler != nil {
+		return handler
+	}
+
+	return r.fallback
+}
@@ -0,0 +1,77 @@
+package pskafka_test
+
+import (
+	"context"
+	"go-start-template/pkg/pskafka"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter(t *testing.T) {
+	var called []string
+	record := func(name string) pskafka.HandleFunc {
+		return func(ctx context.Context, msg kafka.Message) error {
+			called = append(called, name)
+			return nil
+		}
+	}
+	intercept := func(name string) pskafka.InterceptorFunc {
+		return func(ctx context.Context, msg kafka.Message, next pskafka.HandleFunc) error {
+			called = append(called, name)
+			return next(ctx, msg)
+		}
+	}
+
+	router := pskafka.NewRouter(pskafka.HeaderEventType)
+	router.Handle("order.created", record("created"), intercept("audit"))
+	router.HandleKeyPrefix("order:", record("order"))
+	router.HandleKeyPrefix("order:vip:", record("vip"))
+
+	tests := []struct {
+		name string
+		msg  kafka.Message
+		want []string
+	}{
+		{
+			name: "header",
+			msg: kafka.Message{
+				Key:     []byte("order:vip:1"),
+				Headers: []kafka.Header{{Key: pskafka.HeaderEventType, Value: []byte("order.created")}},
+			},
+			want: []string{"audit", "created"},
+		},
+		{
+			name: "longest key prefix",
+			msg:  kafka.Message{Key: []byte("order:vip:1")},
+			want: []string{"vip"},
+		},
+		{
+			name: "unknown header value falls back to key prefix",
+			msg: kafka.Message{
+				Key:     []byte("order:1"),
+				Headers: []kafka.Header{{Key: pskafka.HeaderEventType, Value: []byte("order.paid")}},
+			},
+			want: []string{"order"},
+		},
+		{
+			name: "unroutable message is skipped",
+			msg:  kafka.Message{Key: []byte("user:1")},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = nil
+			require.NoError(t, router.Route(context.Background(), tt.msg))
+			require.Equal(t, tt.want, called)
+		})
+	}
+
+	router.HandleDefault(record("default"))
+	called = nil
+	require.NoError(t, router.Route(context.Background(), kafka.Message{Key: []byte("user:1")}))
+	require.Equal(t, []string{"default"}, called)
+}
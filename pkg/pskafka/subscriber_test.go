@@ -0,0 +1,141 @@
+package pskafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFetcher returns the messages and then blocks until the fetch is canceled.
+type fakeFetcher struct {
+	msgs chan kafka.Message
+}
+
+func newFakeFetcher(msgs ...kafka.Message) *fakeFetcher {
+	f := &fakeFetcher{msgs: make(chan kafka.Message, len(msgs))}
+	for _, m := range msgs {
+		f.msgs <- m
+	}
+	return f
+}
+
+func (f *fakeFetcher) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	select {
+	case m := <-f.msgs:
+		return m, nil
+	case <-ctx.Done():
+		return kafka.Message{}, ctx.Err()
+	}
+}
+
+func newTestSubscriber(t *testing.T) *Subscriber {
+	t.Helper()
+
+	s, err := NewSubscriber(&SubscriberConfig{
+		Brokers:          []string{"localhost:9092"},
+		SecurityProtocol: Plaintext,
+		GroupID:          "test",
+	})
+	require.NoError(t, err)
+
+	return s
+}
+
+// startConsumer handles the messages of the fetcher like Consume does with a single consumer.
+func startConsumer(s *Subscriber, c consumer, f messageFetcher) {
+	go func() {
+		s.handleMessages(c, f)
+		close(s.doneCh)
+	}()
+}
+
+func TestShutdownWaitsForRunningHandler(t *testing.T) {
+	s := newTestSubscriber(t)
+
+	started := make(chan struct{})
+	var handlerErr atomic.Value
+	c := consumer{
+		topic: "test",
+		handler: func(ctx context.Context, msg kafka.Message) error {
+			close(started)
+			time.Sleep(100 * time.Millisecond)
+			handlerErr.Store(fmt.Sprint(ctx.Err()))
+			return nil
+		},
+		state: newConsumerState("test"),
+	}
+	startConsumer(s, c, newFakeFetcher(kafka.Message{Value: []byte("ping")}))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, s.Shutdown(ctx))
+	require.Equal(t, "<nil>", handlerErr.Load(), "the handler finishes with a live context")
+}
+
+func TestShutdownDeadlineCancelsHandler(t *testing.T) {
+	s := newTestSubscriber(t)
+
+	started := make(chan struct{})
+	handlerErr := make(chan error, 1)
+	c := consumer{
+		topic: "test",
+		handler: func(ctx context.Context, msg kafka.Message) error {
+			close(started)
+			<-ctx.Done()
+			handlerErr <- ctx.Err()
+			return ctx.Err()
+		},
+		state: newConsumerState("test"),
+	}
+	startConsumer(s, c, newFakeFetcher(kafka.Message{Value: []byte("ping")}))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	require.True(t, errors.Is(s.Shutdown(ctx), context.DeadlineExceeded))
+	require.True(t, errors.Is(<-handlerErr, context.Canceled))
+}
+
+func TestShutdownStopsThrottledMessage(t *testing.T) {
+	s := newTestSubscriber(t)
+
+	// Signals that the second message reached the rate limit
+	var received atomic.Int32
+	throttled := make(chan struct{})
+	signal := func(ctx context.Context, msg kafka.Message, next HandleFunc) error {
+		if received.Add(1) == 2 {
+			close(throttled)
+		}
+		return next(ctx, msg)
+	}
+
+	var handled atomic.Int32
+	c := consumer{
+		topic: "test",
+		handler: func(ctx context.Context, msg kafka.Message) error {
+			handled.Add(1)
+			return nil
+		},
+		localInterceptors: []InterceptorFunc{signal, RateLimit(RateLimitConfig{PerSecond: 0.01})},
+		state:             newConsumerState("test"),
+	}
+	startConsumer(s, c, newFakeFetcher(kafka.Message{}, kafka.Message{}))
+
+	<-throttled
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, s.Shutdown(ctx))
+	require.Equal(t, int32(1), handled.Load(), "the throttled message is not handled")
+	require.Equal(t, ErrSubscriberStopping.Error(), c.state.health(time.Minute).Error)
+}
@@ -0,0 +1,60 @@
+// Package requestid identifies a request across logs and the calls made while handling it.
+//
+// The ID is read from the X-Request-ID header of the incoming request or generated,
+// stored in the context and propagated to outgoing HTTP, gRPC and Kafka calls,
+// so the logs of all services handling the request can be correlated.
+package requestid
+
+import (
+	"context"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Header is the HTTP header carrying the request ID.
+	Header = "X-Request-ID"
+
+	// MetadataKey is the gRPC metadata key carrying the request ID.
+	MetadataKey = "x-request-id"
+
+	// LogKey is the key of the request ID in log attributes.
+	LogKey = "request_id"
+
+	// maxLength limits the length of request IDs received from clients.
+	maxLength = 128
+)
+
+type requestIDKey struct{}
+
+// New returns a new random request ID.
+func New() string {
+	return uuid.NewString()
+}
+
+// Valid reports whether the request ID received from a client can be used.
+// Request IDs must be non-empty, at most 128 characters long and consist of printable ASCII characters,
+// so they can not break log lines or headers.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for _, r := range id {
+		if r > unicode.MaxASCII || !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewContext returns a copy of the context carrying the request ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// FromContext returns the request ID carried by the context, or an empty string.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,57 @@
+package requestid_test
+
+import (
+	"context"
+	"go-start-template/pkg/requestid"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestValid(t *testing.T) {
+	require.True(t, requestid.Valid(requestid.New()))
+	require.True(t, requestid.Valid("req-1"))
+	require.False(t, requestid.Valid(""))
+	require.False(t, requestid.Valid(strings.Repeat("a", 129)))
+	require.False(t, requestid.Valid("req\n1"))
+	require.False(t, requestid.Valid("réq"))
+}
+
+func TestTransport(t *testing.T) {
+	var header string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get(requestid.Header)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &requestid.Transport{}}
+
+	ctx := requestid.NewContext(context.Background(), "req-1")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Equal(t, "req-1", header)
+	require.Empty(t, req.Header.Get(requestid.Header), "request of the caller must not be modified")
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	var md metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	ctx := requestid.NewContext(context.Background(), "req-1")
+	err := requestid.UnaryClientInterceptor()(ctx, "/svc/Method", nil, nil, nil, invoker)
+	require.NoError(t, err)
+	require.Equal(t, []string{"req-1"}, md.Get(requestid.MetadataKey))
+}